@@ -0,0 +1,52 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type DomainAvailability struct {
+	Avail          string      `json:"avail"`
+	Type           string      `json:"type"`
+	Price          string      `json:"price"`
+	RegularPrice   string      `json:"regularPrice"`
+	FirstYearPromo string      `json:"firstYearPromo"`
+	Premium        string      `json:"premium"`
+	MinDuration    json.Number `json:"minDuration"`
+	Additional     struct {
+		Renewal  DomainAvailabilityPrice `json:"renewal"`
+		Transfer DomainAvailabilityPrice `json:"transfer"`
+	} `json:"additional"`
+}
+
+type DomainAvailabilityPrice struct {
+	Type         string `json:"type"`
+	Price        string `json:"price"`
+	RegularPrice string `json:"regularPrice"`
+}
+
+// CheckDomain returns the availability and pricing of the domain. Porkbun
+// limits how often domains may be checked, so callers should expect
+// ErrRateLimited when checking many domains at once.
+func (c *Client) CheckDomain(
+	ctx context.Context,
+	domain string,
+) (
+	*DomainAvailability,
+	error,
+) {
+	path := "domain/checkDomain/" + domain
+	req := &Credentials{}
+	var res struct {
+		Status
+		Response DomainAvailability `json:"response"`
+	}
+	err := c.post(ctx, path, req, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res.Response, nil
+}
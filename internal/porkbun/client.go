@@ -11,37 +11,51 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Client struct {
 	Credentials
 	url    *url.URL
 	client *http.Client
+	retry  *RetryPolicy
+}
+
+// DefaultURL returns the base URL of the Porkbun API, optionally using the
+// dedicated IPv4 only hostname.
+func DefaultURL(forceIPv4 bool) *url.URL {
+	host := "porkbun.com"
+	if forceIPv4 {
+		host = "api-ipv4.porkbun.com"
+	}
+	return &url.URL{
+		Scheme: "https",
+		Host:   host,
+		Path:   "api/json/v3",
+	}
 }
 
 func NewClient(
 	client *http.Client,
+	baseURL *url.URL,
 	apiKey string,
 	secretAPIKey string,
-	forceIPv4 bool,
+	retry *RetryPolicy,
 ) *Client {
-	host := "porkbun.com"
-	if forceIPv4 {
-		host = "api-ipv4.porkbun.com"
+	if baseURL == nil {
+		baseURL = DefaultURL(false)
+	}
+	if retry == nil {
+		retry = DefaultRetryPolicy()
 	}
-	scheme := "https"
-	path := "api/json/v3"
 	return &Client{
 		Credentials: Credentials{
 			APIKey:       apiKey,
 			SecretAPIKey: secretAPIKey,
 		},
-		url: &url.URL{
-			Scheme: scheme,
-			Host:   host,
-			Path:   path,
-		},
+		url:    baseURL,
 		client: client,
+		retry:  retry,
 	}
 }
 
@@ -50,7 +64,7 @@ func (c *Client) get(
 	path string,
 	res status,
 ) error {
-	return c.do(ctx, http.MethodGet, path, nil, res)
+	return c.do(ctx, http.MethodGet, path, nil, res, retryIdempotent)
 }
 
 func (c *Client) post(
@@ -58,6 +72,27 @@ func (c *Client) post(
 	path string,
 	req credentials,
 	res status,
+) error {
+	return c.postWithRetry(ctx, path, req, res, retryIdempotent)
+}
+
+// postCreate makes an API request that isn't idempotent, e.g. one that creates
+// a record, so it is only retried if Porkbun certainly didn't process it.
+func (c *Client) postCreate(
+	ctx context.Context,
+	path string,
+	req credentials,
+	res status,
+) error {
+	return c.postWithRetry(ctx, path, req, res, retryUnprocessed)
+}
+
+func (c *Client) postWithRetry(
+	ctx context.Context,
+	path string,
+	req credentials,
+	res status,
+	mode retryMode,
 ) error {
 	req.setAPIKey(c.APIKey)
 	req.setSecretAPIKey(c.SecretAPIKey)
@@ -70,21 +105,57 @@ func (c *Client) post(
 		)
 	}
 
-	return c.do(ctx, http.MethodPost, path, bytes.NewReader(body), res)
+	return c.do(ctx, http.MethodPost, path, body, res, mode)
 }
 
 func (c *Client) do(
 	ctx context.Context,
 	method string,
 	path string,
-	body io.Reader,
+	body []byte,
 	response status,
+	mode retryMode,
 ) error {
+	for attempt := int64(0); ; attempt++ {
+		retryAfter, retryable, unprocessed, err := c.attempt(ctx, method, path, body, response)
+		if err == nil || !mode.allows(retryable, unprocessed) || attempt >= c.retry.MaxRetries {
+			return err
+		}
+		if err := c.retry.wait(ctx, attempt, retryAfter); err != nil {
+			return fmt.Errorf(
+				"Gave up retrying an API request after %d attempts "+
+					"with the following error: %w",
+				attempt+1,
+				err,
+			)
+		}
+	}
+}
+
+// attempt makes a single API request. Besides the error, it reports whether
+// the request is worth retrying, whether Porkbun certainly didn't process it
+// and how long the server asked us to wait.
+func (c *Client) attempt(
+	ctx context.Context,
+	method string,
+	path string,
+	body []byte,
+	response status,
+) (
+	time.Duration,
+	bool,
+	bool,
+	error,
+) {
 	url := c.url.JoinPath(path).String()
 
-	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
 	if err != nil {
-		return fmt.Errorf(
+		return 0, false, false, fmt.Errorf(
 			"Failed to create an HTPP request "+
 				"with the following error: %s",
 			err,
@@ -95,46 +166,41 @@ func (c *Client) do(
 
 	res, err := c.client.Do(req)
 	if err != nil {
-		return fmt.Errorf(
+		retryable := ctx.Err() == nil && isRetryableTransportError(err)
+		return 0, retryable, isUnsentTransportError(err), fmt.Errorf(
 			"Failed to process an HTTP request "+
-				"with the following error: %s",
+				"with the following error: %w",
 			err,
 		)
 	}
 	defer res.Body.Close()
 
+	retryAfter := parseRetryAfter(res.Header.Get("Retry-After"))
+
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
-		return fmt.Errorf(
+		return retryAfter, true, false, fmt.Errorf(
 			"Failed to read response body "+
-				"with the following error: %s",
+				"with the following error: %w",
 			err,
 		)
 	}
 
+	apiErr := &APIError{
+		Path:       path,
+		HTTPStatus: res.StatusCode,
+	}
+
 	err = json.Unmarshal(b, response)
 	if err != nil {
-		return fmt.Errorf(
-			"Failed to unmarshal an API response as JSON "+
-				"with the following error: %s",
-			err,
-		)
+		apiErr.Err = err
+		return retryAfter, apiErr.retryable(), apiErr.unprocessed(), apiErr
 	}
 
-	switch response.getStatus() {
-	case "SUCCESS":
-		return nil
-	case "ERROR":
-		return fmt.Errorf(
-			"Received 'ERROR' response status "+
-				"with the following message: %s",
-			response.getMessage(),
-		)
-	default:
-		return fmt.Errorf(
-			"Received unknown response status. "+
-				"Expected 'SUCCESS' or 'ERROR', got: '%s'.",
-			response.getStatus(),
-		)
+	apiErr.Status = response.getStatus()
+	apiErr.Message = response.getMessage()
+	if apiErr.Status == "SUCCESS" {
+		return 0, false, false, nil
 	}
+	return retryAfter, apiErr.retryable(), apiErr.unprocessed(), apiErr
 }
@@ -0,0 +1,48 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newTestClient returns a client for a server that responds to each path with
+// the body in responses, and with a Porkbun error to any other path. Request
+// bodies are sent to requests, if it isn't nil, keyed by path.
+func newTestClient(
+	t *testing.T,
+	responses map[string]string,
+	requests map[string]map[string]any,
+) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/")
+		if requests != nil {
+			body := map[string]any{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			requests[path] = body
+		}
+		body, ok := responses[path]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"status": "ERROR", "message": "Unexpected path %s."}`, path)
+			return
+		}
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	baseURL, err := url.Parse(server.URL + "/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewClient(server.Client(), baseURL, "pk1_test", "sk1_test", &RetryPolicy{})
+}
@@ -30,7 +30,7 @@ type dnsrecord struct {
 	Notes     string      `json:"notes"`
 }
 
-func (r *DNSRecord) convert() *dnsrecord {
+func (r *DNSRecord) convert(domain string) *dnsrecord {
 	id := ""
 	if r.ID != nil {
 		id = strconv.FormatInt(*r.ID, 10)
@@ -43,7 +43,7 @@ func (r *DNSRecord) convert() *dnsrecord {
 
 	return &dnsrecord{
 		ID:        json.Number(id),
-		Subdomain: r.Subdomain,
+		Subdomain: RelativeName(r.Subdomain, domain),
 		Type:      r.Type,
 		Content:   r.Content,
 		TTL:       json.Number(strconv.FormatInt(r.TTL, 10)),
@@ -52,15 +52,17 @@ func (r *DNSRecord) convert() *dnsrecord {
 	}
 }
 
-func (r *dnsrecord) convert() (*DNSRecord, error) {
+// convert parses a record returned by Porkbun. Porkbun returns fully
+// qualified names, so they are converted back to relative subdomains.
+func (r *dnsrecord) convert(domain string) (*DNSRecord, error) {
 	record := &DNSRecord{
-		Subdomain: r.Subdomain,
+		Subdomain: RelativeName(r.Subdomain, domain),
 		Type:      r.Type,
 		Content:   r.Content,
 		Notes:     r.Notes,
 	}
 
-	if r.ID.String() == "" {
+	if r.ID.String() != "" {
 		id, err := r.ID.Int64()
 		if err != nil {
 			return nil, fmt.Errorf(
@@ -68,7 +70,7 @@ func (r *dnsrecord) convert() (*DNSRecord, error) {
 				err.Error(),
 			)
 		}
-		*record.ID = id
+		record.ID = &id
 	}
 
 	ttl, err := r.TTL.Int64()
@@ -115,10 +117,17 @@ func (c *Client) DNSRecords(
 	if err != nil {
 		return nil, []error{err}
 	}
+	if id != nil && len(res.Records) == 0 {
+		return nil, []error{fmt.Errorf(
+			"Failed to find DNS record with the id '%d': %w",
+			*id,
+			ErrNotFound,
+		)}
+	}
 	records := []*DNSRecord{}
 	errs := []error{}
 	for _, record := range res.Records {
-		r, err := record.convert()
+		r, err := record.convert(domain)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -137,6 +146,7 @@ func (c *Client) DNSRecordsByTypeName(
 	[]error,
 ) {
 	path := "dns/retrieveByNameType/" + domain + "/" + type_
+	subdomain = RelativeName(subdomain, domain)
 	if subdomain != "" {
 		path += "/" + subdomain
 	}
@@ -152,7 +162,7 @@ func (c *Client) DNSRecordsByTypeName(
 	records := []*DNSRecord{}
 	errs := []error{}
 	for _, record := range res.Records {
-		r, err := record.convert()
+		r, err := record.convert(domain)
 		if err != nil {
 			errs = append(errs, err)
 		}
@@ -173,12 +183,12 @@ func (c *Client) CreateDNSRecord(
 	req := &struct {
 		Credentials
 		dnsrecord
-	}{dnsrecord: *record.convert()}
+	}{dnsrecord: *record.convert(domain)}
 	var res struct {
 		Status
 		ID int64 `json:"id"`
 	}
-	err := c.post(ctx, path, req, &res)
+	err := c.postCreate(ctx, path, req, &res)
 	return res.ID, err
 }
 
@@ -191,7 +201,7 @@ func (c *Client) EditDNSRecord(
 	req := &struct {
 		Credentials
 		dnsrecord
-	}{dnsrecord: *record.convert()}
+	}{dnsrecord: *record.convert(domain)}
 	var res Status
 	err := c.post(ctx, path, req, &res)
 	return err
@@ -208,3 +218,43 @@ func (c *Client) DeleteDNSRecord(
 	err := c.post(ctx, path, req, &res)
 	return err
 }
+
+// EditDNSRecordsByNameType sets the content, ttl, priority and notes of every
+// record of the type on the subdomain to the ones of the given record.
+func (c *Client) EditDNSRecordsByNameType(
+	ctx context.Context,
+	domain string,
+	type_ string,
+	subdomain string,
+	record *DNSRecord,
+) error {
+	path := "dns/editByNameType/" + domain + "/" + type_
+	subdomain = RelativeName(subdomain, domain)
+	if subdomain != "" {
+		path += "/" + subdomain
+	}
+	req := &struct {
+		Credentials
+		dnsrecord
+	}{dnsrecord: *record.convert(domain)}
+	var res Status
+	err := c.post(ctx, path, req, &res)
+	return err
+}
+
+func (c *Client) DeleteDNSRecordsByNameType(
+	ctx context.Context,
+	domain string,
+	type_ string,
+	subdomain string,
+) error {
+	path := "dns/deleteByNameType/" + domain + "/" + type_
+	subdomain = RelativeName(subdomain, domain)
+	if subdomain != "" {
+		path += "/" + subdomain
+	}
+	req := &Credentials{}
+	var res Status
+	err := c.post(ctx, path, req, &res)
+	return err
+}
@@ -0,0 +1,191 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+type DNSSECRecord struct {
+	KeyTag           int64
+	Algorithm        int64
+	DigestType       int64
+	Digest           string
+	MaxSigLife       *int64
+	KeyDataFlags     *int64
+	KeyDataProtocol  *int64
+	KeyDataAlgorithm *int64
+	KeyDataPublicKey string
+}
+
+type dnssecrecord struct {
+	KeyTag           json.Number `json:"keyTag"`
+	Algorithm        json.Number `json:"alg"`
+	DigestType       json.Number `json:"digestType"`
+	Digest           string      `json:"digest"`
+	MaxSigLife       json.Number `json:"maxSigLife,omitempty"`
+	KeyDataFlags     json.Number `json:"keyDataFlags,omitempty"`
+	KeyDataProtocol  json.Number `json:"keyDataProtocol,omitempty"`
+	KeyDataAlgorithm json.Number `json:"keyDataAlgo,omitempty"`
+	KeyDataPublicKey string      `json:"keyDataPubKey,omitempty"`
+}
+
+func (r *DNSSECRecord) convert() *dnssecrecord {
+	optional := func(n *int64) json.Number {
+		if n == nil {
+			return ""
+		}
+		return json.Number(strconv.FormatInt(*n, 10))
+	}
+	return &dnssecrecord{
+		KeyTag:           json.Number(strconv.FormatInt(r.KeyTag, 10)),
+		Algorithm:        json.Number(strconv.FormatInt(r.Algorithm, 10)),
+		DigestType:       json.Number(strconv.FormatInt(r.DigestType, 10)),
+		Digest:           r.Digest,
+		MaxSigLife:       optional(r.MaxSigLife),
+		KeyDataFlags:     optional(r.KeyDataFlags),
+		KeyDataProtocol:  optional(r.KeyDataProtocol),
+		KeyDataAlgorithm: optional(r.KeyDataAlgorithm),
+		KeyDataPublicKey: r.KeyDataPublicKey,
+	}
+}
+
+func (r *dnssecrecord) convert() (*DNSSECRecord, error) {
+	keyTag, err := r.KeyTag.Int64()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to parse key tag as an integer with the following error: '%s'.",
+			err.Error(),
+		)
+	}
+	algorithm, err := r.Algorithm.Int64()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to parse algorithm as an integer with the following error: '%s'.",
+			err.Error(),
+		)
+	}
+	digestType, err := r.DigestType.Int64()
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to parse digest type as an integer with the following error: '%s'.",
+			err.Error(),
+		)
+	}
+	out := &DNSSECRecord{
+		KeyTag:           keyTag,
+		Algorithm:        algorithm,
+		DigestType:       digestType,
+		Digest:           r.Digest,
+		KeyDataPublicKey: r.KeyDataPublicKey,
+	}
+
+	// The key data and maximum signature life are only returned if they were
+	// set when the record was created.
+	optional := []struct {
+		name  string
+		value json.Number
+		out   **int64
+	}{
+		{"max sig life", r.MaxSigLife, &out.MaxSigLife},
+		{"key data flags", r.KeyDataFlags, &out.KeyDataFlags},
+		{"key data protocol", r.KeyDataProtocol, &out.KeyDataProtocol},
+		{"key data algorithm", r.KeyDataAlgorithm, &out.KeyDataAlgorithm},
+	}
+	for _, field := range optional {
+		if field.value == "" {
+			continue
+		}
+		n, err := field.value.Int64()
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Failed to parse %s as an integer with the following error: '%s'.",
+				field.name,
+				err.Error(),
+			)
+		}
+		*field.out = &n
+	}
+	return out, nil
+}
+
+// DNSSECRecords returns the DS records of the domain at the registry, sorted
+// by key tag.
+func (c *Client) DNSSECRecords(
+	ctx context.Context,
+	domain string,
+) (
+	[]*DNSSECRecord,
+	[]error,
+) {
+	path := "dns/getDnssecRecords/" + domain
+	req := &Credentials{}
+	var res struct {
+		Status
+		Records json.RawMessage `json:"records"`
+	}
+	err := c.post(ctx, path, req, &res)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	// Records are keyed by key tag, but an empty list is sent as an array.
+	byKeyTag := map[string]dnssecrecord{}
+	if len(res.Records) != 0 && string(res.Records) != "[]" && string(res.Records) != "null" {
+		err = json.Unmarshal(res.Records, &byKeyTag)
+		if err != nil {
+			return nil, []error{fmt.Errorf(
+				"Failed to unmarshal DNSSEC records as JSON "+
+					"with the following error: %w",
+				err,
+			)}
+		}
+	}
+
+	records := []*DNSSECRecord{}
+	errs := []error{}
+	for _, record := range byKeyTag {
+		r, err := record.convert()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, r)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].KeyTag < records[j].KeyTag
+	})
+	return records, errs
+}
+
+func (c *Client) CreateDNSSECRecord(
+	ctx context.Context,
+	domain string,
+	record *DNSSECRecord,
+) error {
+	path := "dns/createDnssecRecord/" + domain
+	req := &struct {
+		Credentials
+		dnssecrecord
+	}{dnssecrecord: *record.convert()}
+	var res Status
+	err := c.postCreate(ctx, path, req, &res)
+	return err
+}
+
+func (c *Client) DeleteDNSSECRecord(
+	ctx context.Context,
+	domain string,
+	keyTag int64,
+) error {
+	path := "dns/deleteDnssecRecord/" + domain + "/" + strconv.FormatInt(keyTag, 10)
+	req := &Credentials{}
+	var res Status
+	err := c.post(ctx, path, req, &res)
+	return err
+}
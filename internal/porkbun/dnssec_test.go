@@ -0,0 +1,87 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestDNSSECRecords(t *testing.T) {
+	int64p := func(n int64) *int64 { return &n }
+
+	tests := []struct {
+		name    string
+		body    string
+		records []*DNSSECRecord
+		errs    int
+	}{
+		{
+			name:    "empty",
+			body:    `{"status": "SUCCESS", "records": []}`,
+			records: []*DNSSECRecord{},
+		},
+		{
+			name: "without key data",
+			body: `{"status": "SUCCESS", "records": {"64087": {
+				"keyTag": "64087", "alg": "13", "digestType": "2", "digest": "15E445BD"
+			}}}`,
+			records: []*DNSSECRecord{
+				{KeyTag: 64087, Algorithm: 13, DigestType: 2, Digest: "15E445BD"},
+			},
+		},
+		{
+			name: "with key data",
+			body: `{"status": "SUCCESS", "records": {
+				"64087": {
+					"keyTag": "64087", "alg": "13", "digestType": "2", "digest": "15E445BD",
+					"maxSigLife": "86400", "keyDataFlags": "257", "keyDataProtocol": "3",
+					"keyDataAlgo": "13", "keyDataPubKey": "mdsswUyr3DPW132mOi8V9xESWE8jTo0d"
+				},
+				"2371": {"keyTag": 2371, "alg": 8, "digestType": 1, "digest": "AB12", "keyDataFlags": 256}
+			}}`,
+			records: []*DNSSECRecord{
+				{KeyTag: 2371, Algorithm: 8, DigestType: 1, Digest: "AB12", KeyDataFlags: int64p(256)},
+				{
+					KeyTag:           64087,
+					Algorithm:        13,
+					DigestType:       2,
+					Digest:           "15E445BD",
+					MaxSigLife:       int64p(86400),
+					KeyDataFlags:     int64p(257),
+					KeyDataProtocol:  int64p(3),
+					KeyDataAlgorithm: int64p(13),
+					KeyDataPublicKey: "mdsswUyr3DPW132mOi8V9xESWE8jTo0d",
+				},
+			},
+		},
+		{
+			name: "malformed",
+			body: `{"status": "SUCCESS", "records": {
+				"1": {"keyTag": "1", "alg": "13", "digestType": "2", "digest": "AB", "maxSigLife": "1.5"},
+				"2": {"keyTag": "2", "alg": "13", "digestType": "2", "digest": "CD"}
+			}}`,
+			records: []*DNSSECRecord{
+				{KeyTag: 2, Algorithm: 13, DigestType: 2, Digest: "CD"},
+			},
+			errs: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, map[string]string{
+				"dns/getDnssecRecords/example.com": test.body,
+			}, nil)
+			records, errs := client.DNSSECRecords(context.Background(), "example.com")
+			if len(errs) != test.errs {
+				t.Fatalf("got errors %v, want %d", errs, test.errs)
+			}
+			if !reflect.DeepEqual(records, test.records) {
+				t.Errorf("got records %+v, want %+v", records, test.records)
+			}
+		})
+	}
+}
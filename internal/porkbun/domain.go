@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 type Domain struct {
@@ -52,7 +53,7 @@ func (d *domain) convert() (*Domain, error) {
 			d.SecurityLock.String(),
 		)
 	}
-	switch d.NotLocal.String() {
+	switch d.WhoisPrivacy.String() {
 	case "1":
 		domain.WhoisPrivacy = true
 	case "0":
@@ -63,7 +64,7 @@ func (d *domain) convert() (*Domain, error) {
 			d.WhoisPrivacy.String(),
 		)
 	}
-	switch d.NotLocal.String() {
+	switch d.AutoRenew.String() {
 	case "1":
 		domain.AutoRenew = true
 	case "0":
@@ -114,8 +115,142 @@ func (c *Client) DomainList(
 		d, err := domain.convert()
 		if err != nil {
 			errs = append(errs, err)
+			continue
 		}
 		domains = append(domains, d)
 	}
 	return domains, errs
 }
+
+// Domains returns every domain in the account, paging through DomainList.
+func (c *Client) Domains(
+	ctx context.Context,
+) (
+	[]*Domain,
+	[]error,
+) {
+	all := []*Domain{}
+	for start := int64(0); ; start += 1000 {
+		domains, errs := c.DomainList(ctx, start)
+		if len(errs) != 0 {
+			return nil, errs
+		}
+		all = append(all, domains...)
+		if len(domains) < 1000 {
+			break
+		}
+	}
+	return all, nil
+}
+
+// Domain returns the domain from the account. An error wrapping
+// ErrDomainNotFound is returned if there is no such domain.
+func (c *Client) Domain(
+	ctx context.Context,
+	name string,
+) (
+	*Domain,
+	[]error,
+) {
+	domains, errs := c.Domains(ctx)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	for _, domain := range domains {
+		if strings.EqualFold(domain.Domain, name) {
+			return domain, nil
+		}
+	}
+	return nil, []error{fmt.Errorf(
+		"Failed to find the domain '%s' in the account: %w",
+		name,
+		ErrDomainNotFound,
+	)}
+}
+
+// UpdateAutoRenew turns auto renew on or off for all of the domains in a
+// single request. Porkbun reports the result for each domain separately, so
+// an error is returned for each domain that failed or has no result.
+func (c *Client) UpdateAutoRenew(
+	ctx context.Context,
+	enabled bool,
+	domains []string,
+) []error {
+	path := "domain/updateAutoRenew"
+	status := "off"
+	if enabled {
+		status = "on"
+	}
+	req := &struct {
+		Credentials
+		Status  string   `json:"status"`
+		Domains []string `json:"domains"`
+	}{Status: status, Domains: domains}
+	var res struct {
+		Status
+		Results map[string]Status `json:"results"`
+	}
+	err := c.post(ctx, path, req, &res)
+	if err != nil {
+		return []error{err}
+	}
+
+	// Porkbun may not echo the domains as they were sent, e.g. in lowercase.
+	results := map[string]Status{}
+	for domain, result := range res.Results {
+		results[strings.ToLower(domain)] = result
+	}
+
+	errs := []error{}
+	for _, domain := range domains {
+		result, ok := results[strings.ToLower(domain)]
+		if !ok {
+			errs = append(errs, fmt.Errorf(
+				"Failed to update auto renew for the domain '%s': no result was returned for it",
+				domain,
+			))
+			continue
+		}
+		if result.Status != "SUCCESS" {
+			errs = append(errs, fmt.Errorf(
+				"Failed to update auto renew for the domain '%s': %w",
+				domain,
+				&APIError{Path: path, Status: "ERROR", Message: result.Message},
+			))
+		}
+	}
+	return errs
+}
+
+// CreateDomain registers the domain for its minimum duration. The cost is in
+// pennies and must match the current price of the domain, as returned by
+// CheckDomain, otherwise Porkbun refuses the registration, as it does unless
+// the user agreed to the terms of service. The request is never retried, as a
+// retry after a failure Porkbun processed anyway would charge the account
+// twice.
+func (c *Client) CreateDomain(
+	ctx context.Context,
+	domain string,
+	cost int64,
+	agreeToTerms bool,
+) (
+	string,
+	error,
+) {
+	path := "domain/create/" + domain
+	agree := "no"
+	if agreeToTerms {
+		agree = "yes"
+	}
+	req := &struct {
+		Credentials
+		Cost         int64  `json:"cost"`
+		AgreeToTerms string `json:"agreeToTerms"`
+	}{Cost: cost, AgreeToTerms: agree}
+	var res struct {
+		Status
+		OrderID json.Number `json:"orderId"`
+	}
+	err := c.postWithRetry(ctx, path, req, &res, retryNever)
+	return res.OrderID.String(), err
+}
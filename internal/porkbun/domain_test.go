@@ -0,0 +1,92 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUpdateAutoRenew(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		body    string
+		failed  []string
+	}{
+		{
+			name:    "success",
+			enabled: true,
+			body: `{"status": "SUCCESS", "results": {
+				"example.com": {"status": "SUCCESS", "message": ""},
+				"example.net": {"status": "SUCCESS", "message": ""},
+				"Example.org": {"status": "SUCCESS", "message": ""}
+			}}`,
+		},
+		{
+			name:    "partial failure",
+			enabled: false,
+			body: `{"status": "SUCCESS", "results": {
+				"example.com": {"status": "SUCCESS", "message": ""},
+				"example.net": {"status": "ERROR", "message": "Domain is not in this account."},
+				"example.org": {"status": "SUCCESS", "message": ""}
+			}}`,
+			failed: []string{"example.net"},
+		},
+		{
+			name:    "missing results",
+			enabled: true,
+			body: `{"status": "SUCCESS", "results": {
+				"example.com": {"status": "SUCCESS", "message": ""}
+			}}`,
+			failed: []string{"example.net", "Example.org"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requests := map[string]map[string]any{}
+			client := newTestClient(t, map[string]string{"domain/updateAutoRenew": test.body}, requests)
+			errs := client.UpdateAutoRenew(
+				context.Background(),
+				test.enabled,
+				[]string{"example.com", "example.net", "Example.org"},
+			)
+
+			request := requests["domain/updateAutoRenew"]
+			status := "off"
+			if test.enabled {
+				status = "on"
+			}
+			if request["status"] != status {
+				t.Errorf("sent status %v, want %q", request["status"], status)
+			}
+			domains := []any{"example.com", "example.net", "Example.org"}
+			if !reflect.DeepEqual(request["domains"], domains) {
+				t.Errorf("sent domains %v, want %v", request["domains"], domains)
+			}
+
+			if len(errs) != len(test.failed) {
+				t.Fatalf("got errors %v, want one for each of %q", errs, test.failed)
+			}
+			for i, err := range errs {
+				if !strings.Contains(err.Error(), "'"+test.failed[i]+"'") {
+					t.Errorf("got error %v, want one for %q", err, test.failed[i])
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateAutoRenewFailed(t *testing.T) {
+	client := newTestClient(t, map[string]string{}, nil)
+	errs := client.UpdateAutoRenew(context.Background(), true, []string{"example.com", "example.net"})
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.As(errs[0], &apiErr) {
+		t.Errorf("got errors %v, want a single API error", errs)
+	}
+}
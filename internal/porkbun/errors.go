@@ -0,0 +1,115 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrDomainNotFound = errors.New("domain not found")
+	ErrUnauthorized   = errors.New("unauthorized")
+	ErrRateLimited    = errors.New("rate limited")
+	ErrServer         = errors.New("server error")
+)
+
+// APIError is returned for every request that reached Porkbun but didn't
+// succeed, whether the response was an 'ERROR' status, an unknown status or
+// not JSON at all (Err holds the decoding error in the latter case).
+//
+// Use errors.Is with ErrNotFound, ErrDomainNotFound, ErrUnauthorized,
+// ErrRateLimited and ErrServer to classify it. Porkbun reports most failures
+// as HTTP 400 so the classification relies on the message as well. Only an
+// 'ERROR' response from Porkbun itself is ever classified as not found, so a
+// wrong endpoint or a proxy error page never passes for a deleted object.
+type APIError struct {
+	Path       string
+	HTTPStatus int
+	Status     string
+	Message    string
+	Err        error
+}
+
+func (e *APIError) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf(
+			"Failed to unmarshal an API response from '%s' as JSON "+
+				"(HTTP status %d) with the following error: %s",
+			e.Path,
+			e.HTTPStatus,
+			e.Err,
+		)
+	case e.Status == "ERROR":
+		return fmt.Sprintf(
+			"Received 'ERROR' response status from '%s' "+
+				"(HTTP status %d) with the following message: %s",
+			e.Path,
+			e.HTTPStatus,
+			e.Message,
+		)
+	default:
+		return fmt.Sprintf(
+			"Received unknown response status from '%s'. "+
+				"Expected 'SUCCESS' or 'ERROR', got: '%s'.",
+			e.Path,
+			e.Status,
+		)
+	}
+}
+
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+func (e *APIError) Is(target error) bool {
+	message := strings.ToLower(e.Message)
+	reported := e.Err == nil && e.Status == "ERROR"
+	switch target {
+	case ErrNotFound:
+		if !reported || e.Is(ErrDomainNotFound) {
+			return false
+		}
+		return e.HTTPStatus == http.StatusNotFound ||
+			strings.Contains(message, "not found") ||
+			strings.Contains(message, "could not find") ||
+			strings.Contains(message, "does not exist") ||
+			strings.Contains(message, "invalid record id")
+	case ErrDomainNotFound:
+		if !reported {
+			return false
+		}
+		return strings.Contains(message, "invalid domain") ||
+			strings.Contains(message, "domain not found") ||
+			strings.Contains(message, "not in your account") ||
+			strings.Contains(message, "don't own") ||
+			strings.Contains(message, "do not own")
+	case ErrUnauthorized:
+		return e.HTTPStatus == http.StatusUnauthorized ||
+			e.HTTPStatus == http.StatusForbidden ||
+			strings.Contains(message, "invalid api key") ||
+			strings.Contains(message, "not opted in to api access")
+	case ErrRateLimited:
+		return e.HTTPStatus == http.StatusTooManyRequests ||
+			isRateLimitMessage(e.Message)
+	case ErrServer:
+		return e.HTTPStatus >= 500
+	default:
+		return false
+	}
+}
+
+func (e *APIError) retryable() bool {
+	return isRetryableHTTPStatus(e.HTTPStatus) || errors.Is(e, ErrRateLimited)
+}
+
+// unprocessed reports whether Porkbun refused the request without processing
+// it, which makes it safe to retry even if it isn't idempotent.
+func (e *APIError) unprocessed() bool {
+	return errors.Is(e, ErrRateLimited)
+}
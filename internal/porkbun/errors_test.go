@@ -0,0 +1,123 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorIs(t *testing.T) {
+	decodeErr := errors.New("invalid character '<' looking for beginning of value")
+	sentinels := []error{ErrNotFound, ErrDomainNotFound, ErrUnauthorized, ErrRateLimited, ErrServer}
+
+	tests := []struct {
+		name string
+		err  *APIError
+		want []error
+	}{
+		{
+			name: "record not found",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Invalid record ID."},
+			want: []error{ErrNotFound},
+		},
+		{
+			name: "url forward not found",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Forward could not find the record."},
+			want: []error{ErrNotFound},
+		},
+		{
+			name: "not found status",
+			err:  &APIError{HTTPStatus: 404, Status: "ERROR", Message: "Something went wrong."},
+			want: []error{ErrNotFound},
+		},
+		{
+			name: "not found status without JSON",
+			err:  &APIError{HTTPStatus: 404, Err: decodeErr},
+		},
+		{
+			name: "not found status with an unknown status",
+			err:  &APIError{HTTPStatus: 404, Status: "MAINTENANCE", Message: "Not found."},
+		},
+		{
+			name: "not found message without JSON",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Not found.", Err: decodeErr},
+		},
+		{
+			name: "domain not found",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Domain not found."},
+			want: []error{ErrDomainNotFound},
+		},
+		{
+			name: "domain not found status",
+			err:  &APIError{HTTPStatus: 404, Status: "ERROR", Message: "Invalid domain."},
+			want: []error{ErrDomainNotFound},
+		},
+		{
+			name: "domain not in account",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "This domain is not in your account."},
+			want: []error{ErrDomainNotFound},
+		},
+		{
+			name: "domain not owned",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "You don't own this domain."},
+			want: []error{ErrDomainNotFound},
+		},
+		{
+			name: "domain not found without JSON",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Domain not found.", Err: decodeErr},
+		},
+		{
+			name: "invalid api key",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Invalid API key. (002)"},
+			want: []error{ErrUnauthorized},
+		},
+		{
+			name: "not opted in",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Domain is not opted in to API access."},
+			want: []error{ErrUnauthorized},
+		},
+		{
+			name: "forbidden status",
+			err:  &APIError{HTTPStatus: 403, Err: decodeErr},
+			want: []error{ErrUnauthorized},
+		},
+		{
+			name: "rate limited status",
+			err:  &APIError{HTTPStatus: 429, Err: decodeErr},
+			want: []error{ErrRateLimited},
+		},
+		{
+			name: "rate limited message",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Too many requests, slow down."},
+			want: []error{ErrRateLimited},
+		},
+		{
+			name: "server error",
+			err:  &APIError{HTTPStatus: 502, Err: decodeErr},
+			want: []error{ErrServer},
+		},
+		{
+			name: "server error with a message",
+			err:  &APIError{HTTPStatus: 500, Status: "ERROR", Message: "Record does not exist."},
+			want: []error{ErrNotFound, ErrServer},
+		},
+		{
+			name: "other error",
+			err:  &APIError{HTTPStatus: 400, Status: "ERROR", Message: "Invalid type."},
+		},
+	}
+
+	for _, test := range tests {
+		for _, sentinel := range sentinels {
+			want := false
+			for _, target := range test.want {
+				want = want || target == sentinel
+			}
+			if got := errors.Is(test.err, sentinel); got != want {
+				t.Errorf("%s: errors.Is(err, %q) = %t, want %t", test.name, sentinel, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+type GlueRecord struct {
+	Host string
+	IPs  []string
+}
+
+type gluerecord struct {
+	V4 []string `json:"v4"`
+	V6 []string `json:"v6"`
+}
+
+// GlueRecords returns the glue hosts of the domain at the registry. Hosts are
+// returned as fully qualified names, e.g. 'ns1.example.com'.
+func (c *Client) GlueRecords(
+	ctx context.Context,
+	domain string,
+) (
+	[]*GlueRecord,
+	[]error,
+) {
+	path := "domain/getGlue/" + domain
+	req := &Credentials{}
+	var res struct {
+		Status
+		Hosts []json.RawMessage `json:"hosts"`
+	}
+	err := c.post(ctx, path, req, &res)
+	if err != nil {
+		return nil, []error{err}
+	}
+
+	// Each host is sent as a pair of the host name and its addresses.
+	records := []*GlueRecord{}
+	errs := []error{}
+	for _, raw := range res.Hosts {
+		var pair []json.RawMessage
+		var host string
+		var ips gluerecord
+		err := json.Unmarshal(raw, &pair)
+		if err == nil && len(pair) != 2 {
+			err = fmt.Errorf("expected a pair of host name and addresses, got: %s", raw)
+		}
+		if err == nil {
+			err = json.Unmarshal(pair[0], &host)
+		}
+		if err == nil {
+			err = json.Unmarshal(pair[1], &ips)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf(
+				"Failed to unmarshal glue record as JSON "+
+					"with the following error: %w",
+				err,
+			))
+			continue
+		}
+		records = append(records, &GlueRecord{
+			Host: host,
+			IPs:  append(ips.V4, ips.V6...),
+		})
+	}
+	return records, errs
+}
+
+// GlueRecord returns the glue host of the domain with the subdomain, which may
+// also be given fully qualified. An error wrapping ErrNotFound is returned if
+// there is no such host.
+func (c *Client) GlueRecord(
+	ctx context.Context,
+	domain string,
+	subdomain string,
+) (
+	*GlueRecord,
+	[]error,
+) {
+	records, errs := c.GlueRecords(ctx, domain)
+	if len(errs) != 0 {
+		return nil, errs
+	}
+	for _, record := range records {
+		if SameName(record.Host, subdomain, domain) {
+			return record, nil
+		}
+	}
+	return nil, []error{fmt.Errorf(
+		"Failed to find glue record for the host '%s': %w",
+		FQDN(subdomain, domain),
+		ErrNotFound,
+	)}
+}
+
+func (c *Client) CreateGlueRecord(
+	ctx context.Context,
+	domain string,
+	subdomain string,
+	ips []string,
+) error {
+	path := "domain/createGlue/" + domain + "/" + RelativeName(subdomain, domain)
+	req := &struct {
+		Credentials
+		IPs []string `json:"ips"`
+	}{IPs: ips}
+	var res Status
+	err := c.postCreate(ctx, path, req, &res)
+	return err
+}
+
+func (c *Client) UpdateGlueRecord(
+	ctx context.Context,
+	domain string,
+	subdomain string,
+	ips []string,
+) error {
+	path := "domain/updateGlue/" + domain + "/" + RelativeName(subdomain, domain)
+	req := &struct {
+		Credentials
+		IPs []string `json:"ips"`
+	}{IPs: ips}
+	var res Status
+	err := c.post(ctx, path, req, &res)
+	return err
+}
+
+func (c *Client) DeleteGlueRecord(
+	ctx context.Context,
+	domain string,
+	subdomain string,
+) error {
+	path := "domain/deleteGlue/" + domain + "/" + RelativeName(subdomain, domain)
+	req := &Credentials{}
+	var res Status
+	err := c.post(ctx, path, req, &res)
+	return err
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestGlueRecords(t *testing.T) {
+	tests := []struct {
+		name    string
+		hosts   string
+		records []*GlueRecord
+		errs    int
+	}{
+		{
+			name:    "no hosts",
+			hosts:   `[]`,
+			records: []*GlueRecord{},
+		},
+		{
+			name:    "v4 only",
+			hosts:   `[["ns1.example.com", {"v4": ["192.0.2.1", "192.0.2.2"]}]]`,
+			records: []*GlueRecord{{Host: "ns1.example.com", IPs: []string{"192.0.2.1", "192.0.2.2"}}},
+		},
+		{
+			name:    "v6 only",
+			hosts:   `[["ns1.example.com", {"v6": ["2001:db8::1"]}]]`,
+			records: []*GlueRecord{{Host: "ns1.example.com", IPs: []string{"2001:db8::1"}}},
+		},
+		{
+			name:  "both",
+			hosts: `[["ns1.example.com", {"v4": ["192.0.2.1"], "v6": ["2001:db8::1"]}], ["ns2.example.com", {"v4": ["192.0.2.2"], "v6": []}]]`,
+			records: []*GlueRecord{
+				{Host: "ns1.example.com", IPs: []string{"192.0.2.1", "2001:db8::1"}},
+				{Host: "ns2.example.com", IPs: []string{"192.0.2.2"}},
+			},
+		},
+		{
+			name:  "malformed",
+			hosts: `[["ns1.example.com"], ["ns2.example.com", {"v4": "192.0.2.2"}], [1, {}], {"ns3.example.com": {}}, ["ns4.example.com", {"v4": ["192.0.2.4"]}]]`,
+			records: []*GlueRecord{
+				{Host: "ns4.example.com", IPs: []string{"192.0.2.4"}},
+			},
+			errs: 4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := newTestClient(t, map[string]string{
+				"domain/getGlue/example.com": `{"status": "SUCCESS", "hosts": ` + test.hosts + `}`,
+			}, nil)
+			records, errs := client.GlueRecords(context.Background(), "example.com")
+			if len(errs) != test.errs {
+				t.Fatalf("got errors %v, want %d", errs, test.errs)
+			}
+			if !reflect.DeepEqual(records, test.records) {
+				t.Errorf("got records %+v, want %+v", records, test.records)
+			}
+		})
+	}
+}
+
+func TestGlueRecord(t *testing.T) {
+	client := newTestClient(t, map[string]string{
+		"domain/getGlue/example.com": `{"status": "SUCCESS", "hosts": [["ns1.example.com", {"v4": ["192.0.2.1"]}]]}`,
+	}, nil)
+
+	for _, subdomain := range []string{"ns1", "NS1", "ns1.example.com", "ns1.Example.com."} {
+		record, errs := client.GlueRecord(context.Background(), "example.com", subdomain)
+		if len(errs) != 0 || record == nil || record.Host != "ns1.example.com" {
+			t.Errorf("GlueRecord(%q) = %+v, %v, want ns1.example.com", subdomain, record, errs)
+		}
+	}
+
+	_, errs := client.GlueRecord(context.Background(), "example.com", "ns2")
+	if len(errs) != 1 || !errors.Is(errs[0], ErrNotFound) {
+		t.Errorf("GlueRecord(%q) = %v, want an error wrapping %v", "ns2", errs, ErrNotFound)
+	}
+}
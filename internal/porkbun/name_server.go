@@ -36,3 +36,11 @@ func (c *Client) UpdateNameServers(
 	err := c.post(ctx, path, req, &res)
 	return err
 }
+
+// DefaultNameServers are the name servers Porkbun assigns to new domains.
+var DefaultNameServers = []string{
+	"curitiba.ns.porkbun.com",
+	"fortaleza.ns.porkbun.com",
+	"maceio.ns.porkbun.com",
+	"salvador.ns.porkbun.com",
+}
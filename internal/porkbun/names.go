@@ -0,0 +1,41 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import "strings"
+
+// RelativeName converts a record name to the form Porkbun expects in requests:
+// the subdomain without the domain, or an empty string for the root domain.
+// It accepts relative names, fully qualified names with or without the
+// trailing dot and "@" for the root domain. Wildcards are kept as is, so both
+// "*" and "*.example.com" become "*" for the domain "example.com".
+func RelativeName(name string, domain string) string {
+	name = strings.TrimSuffix(name, ".")
+	domain = strings.TrimSuffix(domain, ".")
+	if name == "@" || strings.EqualFold(name, domain) {
+		return ""
+	}
+	suffix := "." + domain
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		return name[:len(name)-len(suffix)]
+	}
+	return name
+}
+
+// FQDN converts a record name in any of the forms accepted by RelativeName
+// to a fully qualified name without the trailing dot.
+func FQDN(name string, domain string) string {
+	name = RelativeName(name, domain)
+	domain = strings.TrimSuffix(domain, ".")
+	if name == "" {
+		return domain
+	}
+	return name + "." + domain
+}
+
+// SameName reports whether two record names of the domain refer to the same
+// host, ignoring case and the form they are written in.
+func SameName(a string, b string, domain string) bool {
+	return strings.EqualFold(RelativeName(a, domain), RelativeName(b, domain))
+}
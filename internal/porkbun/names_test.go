@@ -0,0 +1,81 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import "testing"
+
+func TestRelativeName(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+	}{
+		{"", "example.com", ""},
+		{"@", "example.com", ""},
+		{"example.com", "example.com", ""},
+		{"example.com.", "example.com", ""},
+		{"EXAMPLE.com", "example.com", ""},
+		{"www", "example.com", "www"},
+		{"www.example.com", "example.com", "www"},
+		{"www.example.com.", "example.com", "www"},
+		{"WWW.Example.COM", "example.com", "WWW"},
+		{"a.b.example.com", "example.com", "a.b"},
+		{"*", "example.com", "*"},
+		{"*.example.com", "example.com", "*"},
+		{"www.example.com", "example.com.", "www"},
+		{"www.notexample.com", "example.com", "www.notexample.com"},
+		{"example.com.evil", "example.com", "example.com.evil"},
+	}
+
+	for _, test := range tests {
+		got := RelativeName(test.name, test.domain)
+		if got != test.want {
+			t.Errorf("RelativeName(%q, %q) = %q, want %q", test.name, test.domain, got, test.want)
+		}
+	}
+}
+
+func TestFQDN(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		want   string
+	}{
+		{"", "example.com", "example.com"},
+		{"@", "example.com", "example.com"},
+		{"www", "example.com", "www.example.com"},
+		{"www.example.com.", "example.com", "www.example.com"},
+		{"*", "example.com.", "*.example.com"},
+	}
+
+	for _, test := range tests {
+		got := FQDN(test.name, test.domain)
+		if got != test.want {
+			t.Errorf("FQDN(%q, %q) = %q, want %q", test.name, test.domain, got, test.want)
+		}
+	}
+}
+
+func TestSameName(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want bool
+	}{
+		{"", "@", true},
+		{"", "example.com.", true},
+		{"www", "WWW.example.com", true},
+		{"www", "www.example.com.", true},
+		{"www", "mail", false},
+		{"www", "", false},
+		{"*", "*.example.com", true},
+	}
+
+	for _, test := range tests {
+		got := SameName(test.a, test.b, "example.com")
+		if got != test.want {
+			t.Errorf("SameName(%q, %q) = %t, want %t", test.a, test.b, got, test.want)
+		}
+	}
+}
@@ -21,8 +21,8 @@ func (c *Client) Pricing(
 	path := "pricing/get"
 	var res struct {
 		Status
-		pricing map[string]Pricing `json:"pricing"`
+		Pricing map[string]Pricing `json:"pricing"`
 	}
 	err := c.get(ctx, path, &res)
-	return res.pricing, err
+	return res.Pricing, err
 }
@@ -0,0 +1,164 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// retryMode tells which failures of an API request are safe to retry.
+type retryMode int
+
+const (
+	// retryIdempotent retries every transient failure, for requests that
+	// have the same effect no matter how often they are made.
+	retryIdempotent retryMode = iota
+	// retryUnprocessed only retries failures where Porkbun certainly didn't
+	// process the request, i.e. it was rate limited or never sent, so that
+	// e.g. a record isn't created twice.
+	retryUnprocessed
+	// retryNever doesn't retry at all.
+	retryNever
+)
+
+// allows reports whether a failure is retried in the mode, given whether it
+// is transient and whether Porkbun certainly didn't process the request.
+func (m retryMode) allows(retryable bool, unprocessed bool) bool {
+	switch m {
+	case retryIdempotent:
+		return retryable
+	case retryUnprocessed:
+		return retryable && unprocessed
+	default:
+		return false
+	}
+}
+
+type RetryPolicy struct {
+	MaxRetries int64
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxRetries: 3,
+		MinBackoff: 1 * time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+}
+
+// backoff returns how long to sleep before the given retry attempt (starting
+// at 0). The delay grows exponentially from MinBackoff and is capped at
+// MaxBackoff, with the upper half of it randomized so that concurrent
+// requests don't retry in lockstep. A server supplied Retry-After delay takes
+// precedence if it is longer, but is still capped at MaxBackoff.
+func (p *RetryPolicy) backoff(
+	attempt int64,
+	retryAfter time.Duration,
+) time.Duration {
+	delay := p.MinBackoff
+	for i := int64(0); i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	if delay > 1 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	return delay
+}
+
+func (p *RetryPolicy) wait(
+	ctx context.Context,
+	attempt int64,
+	retryAfter time.Duration,
+) error {
+	timer := time.NewTimer(p.backoff(attempt, retryAfter))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRetryableTransportError reports whether an error returned by
+// http.Client.Do is likely transient, i.e. a timeout or a connection that
+// was refused, reset or closed before a response was received. The timeout
+// of the HTTP client wraps context.DeadlineExceeded as well, the caller
+// checks whether its own context is done.
+func isRetryableTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	return errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// isUnsentTransportError reports whether an error returned by
+// http.Client.Do happened before the request was sent, i.e. while resolving
+// the host or connecting to it or the proxy.
+func isUnsentTransportError(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) &&
+		(opErr.Op == "dial" || opErr.Op == "proxyconnect")
+}
+
+func isRetryableHTTPStatus(code int) bool {
+	return code == http.StatusTooManyRequests ||
+		code == http.StatusInternalServerError ||
+		code == http.StatusBadGateway ||
+		code == http.StatusServiceUnavailable ||
+		code == http.StatusGatewayTimeout
+}
+
+func isRateLimitMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "rate limit") ||
+		strings.Contains(message, "too many")
+}
+
+// parseRetryAfter parses the Retry-After header which holds either a number
+// of seconds or an HTTP date. It returns 0 if the header is absent or invalid.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseInt(header, 10, 64); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(header); err == nil {
+		if d := time.Until(date); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,207 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package porkbun
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		MaxRetries: 5,
+		MinBackoff: 1 * time.Second,
+		MaxBackoff: 10 * time.Second,
+	}
+
+	tests := []struct {
+		name       string
+		attempt    int64
+		retryAfter time.Duration
+		min        time.Duration
+		max        time.Duration
+	}{
+		{"first attempt", 0, 0, 500 * time.Millisecond, 1 * time.Second},
+		{"second attempt", 1, 0, 1 * time.Second, 2 * time.Second},
+		{"third attempt", 2, 0, 2 * time.Second, 4 * time.Second},
+		{"capped", 10, 0, 5 * time.Second, 10 * time.Second},
+		{"short retry after", 0, 100 * time.Millisecond, 500 * time.Millisecond, 1 * time.Second},
+		{"long retry after", 0, 7 * time.Second, 7 * time.Second, 7 * time.Second},
+		{"retry after capped", 0, time.Minute, 10 * time.Second, 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				delay := policy.backoff(test.attempt, test.retryAfter)
+				if delay < test.min || delay > test.max {
+					t.Fatalf("backoff(%d, %s) = %s, want between %s and %s",
+						test.attempt, test.retryAfter, delay, test.min, test.max)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"absent", "", 0, 0},
+		{"seconds", "5", 5 * time.Second, 5 * time.Second},
+		{"zero", "0", 0, 0},
+		{"negative", "-3", 0, 0},
+		{"invalid", "soon", 0, 0},
+		{"future date", time.Now().Add(30 * time.Second).UTC().Format(http.TimeFormat), 28 * time.Second, 30 * time.Second},
+		{"past date", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseRetryAfter(test.header)
+			if got < test.min || got > test.max {
+				t.Fatalf("parseRetryAfter(%q) = %s, want between %s and %s",
+					test.header, got, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryModeAllows(t *testing.T) {
+	tests := []struct {
+		mode        retryMode
+		retryable   bool
+		unprocessed bool
+		want        bool
+	}{
+		{retryIdempotent, true, false, true},
+		{retryIdempotent, true, true, true},
+		{retryIdempotent, false, false, false},
+		{retryUnprocessed, true, true, true},
+		{retryUnprocessed, true, false, false},
+		{retryUnprocessed, false, true, false},
+		{retryNever, true, true, false},
+		{retryNever, true, false, false},
+	}
+
+	for _, test := range tests {
+		got := test.mode.allows(test.retryable, test.unprocessed)
+		if got != test.want {
+			t.Errorf("retryMode(%d).allows(%t, %t) = %t, want %t",
+				test.mode, test.retryable, test.unprocessed, got, test.want)
+		}
+	}
+}
+
+func TestIsUnsentTransportError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Post", URL: "https://porkbun.com/api/json/v3/ping", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"dial", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}), true},
+		{"proxy connect", wrap(&net.OpError{Op: "proxyconnect", Net: "tcp", Err: errors.New("connection refused")}), true},
+		{"lookup", wrap(&net.OpError{Op: "dial", Net: "tcp", Err: &net.DNSError{Err: "no such host", Name: "porkbun.com"}}), true},
+		{"read", wrap(&net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}), false},
+		{"eof", wrap(io.EOF), false},
+		{"canceled", wrap(context.Canceled), false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := isUnsentTransportError(test.err)
+			if got != test.want {
+				t.Fatalf("isUnsentTransportError(%v) = %t, want %t", test.err, got, test.want)
+			}
+		})
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	type response struct {
+		code  int
+		body  string
+		delay time.Duration
+	}
+	success := response{http.StatusOK, `{"status":"SUCCESS"}`, 0}
+	badGateway := response{http.StatusBadGateway, `<html>Bad Gateway</html>`, 0}
+	tooMany := response{http.StatusTooManyRequests, `{"status":"ERROR","message":"Too many requests."}`, 0}
+	rateLimited := response{http.StatusServiceUnavailable, `{"status":"ERROR","message":"You have exceeded the rate limit."}`, 0}
+	invalid := response{http.StatusBadRequest, `{"status":"ERROR","message":"Invalid type."}`, 0}
+	slow := response{http.StatusOK, `{"status":"SUCCESS"}`, 500 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		mode      retryMode
+		responses []response
+		wantCalls int64
+		wantErr   bool
+	}{
+		{"idempotent success", retryIdempotent, []response{success}, 1, false},
+		{"idempotent bad gateway", retryIdempotent, []response{badGateway, success}, 2, false},
+		{"idempotent timeout", retryIdempotent, []response{slow, success}, 2, false},
+		{"idempotent gives up", retryIdempotent, []response{badGateway, badGateway, badGateway, badGateway}, 3, true},
+		{"idempotent invalid", retryIdempotent, []response{invalid, success}, 1, true},
+		{"create bad gateway", retryUnprocessed, []response{badGateway, success}, 1, true},
+		{"create timeout", retryUnprocessed, []response{slow, success}, 1, true},
+		{"create too many requests", retryUnprocessed, []response{tooMany, success}, 2, false},
+		{"create rate limited", retryUnprocessed, []response{rateLimited, success}, 2, false},
+		{"never too many requests", retryNever, []response{tooMany, success}, 1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls atomic.Int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := calls.Add(1)
+				res := test.responses[min(int(n), len(test.responses))-1]
+				time.Sleep(res.delay)
+				w.WriteHeader(res.code)
+				fmt.Fprint(w, res.body)
+			}))
+			defer server.Close()
+
+			baseURL, err := url.Parse(server.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := NewClient(
+				&http.Client{Timeout: 200 * time.Millisecond},
+				baseURL,
+				"pk1_test",
+				"sk1_test",
+				&RetryPolicy{
+					MaxRetries: 2,
+					MinBackoff: time.Millisecond,
+					MaxBackoff: time.Millisecond,
+				},
+			)
+
+			var res Status
+			err = client.postWithRetry(context.Background(), "dns/create/example.com", &Credentials{}, &res, test.mode)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got error %v, want error: %t", err, test.wantErr)
+			}
+			if got := calls.Load(); got != test.wantCalls {
+				t.Fatalf("got %d calls, want %d", got, test.wantCalls)
+			}
+		})
+	}
+}
@@ -24,7 +24,7 @@ type urlforward struct {
 	Subdomain   string      `json:"subdomain"`
 	Location    string      `json:"location"`
 	Type        string      `json:"type"`
-	IncludePath string      `json:"type"`
+	IncludePath string      `json:"includePath"`
 	Wildcard    string      `json:"wildcard"`
 }
 
@@ -119,6 +119,32 @@ func (c *Client) URLForwards(
 	return forwards, errs
 }
 
+// URLForward returns the url forward with the given id, or an error wrapping
+// ErrNotFound if the domain has no such forward.
+func (c *Client) URLForward(
+	ctx context.Context,
+	domain string,
+	id int64,
+) (
+	*URLForward,
+	[]error,
+) {
+	forwards, errs := c.URLForwards(ctx, domain)
+	if errs != nil && len(errs) != 0 {
+		return nil, errs
+	}
+	for _, forward := range forwards {
+		if *forward.ID == id {
+			return forward, nil
+		}
+	}
+	return nil, []error{fmt.Errorf(
+		"Failed to find url forward with the id '%d': %w",
+		id,
+		ErrNotFound,
+	)}
+}
+
 func (c *Client) AddURLForward(
 	ctx context.Context,
 	domain string,
@@ -130,7 +156,7 @@ func (c *Client) AddURLForward(
 		urlforward
 	}{urlforward: *forward.convert()}
 	var res Status
-	err := c.post(ctx, path, req, &res)
+	err := c.postCreate(ctx, path, req, &res)
 	return err
 }
 
@@ -0,0 +1,188 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/hkdf"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// parseCertificates parses every PEM encoded certificate in the chain, with
+// the leaf certificate first as Porkbun returns it.
+func parseCertificates(chain string) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"Failed to parse certificate with the following error: '%s'.",
+				err.Error(),
+			)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("Expected at least one PEM encoded certificate, got none.")
+	}
+	return certs, nil
+}
+
+// parsePrivateKey parses a PEM encoded private key in the PKCS #8, PKCS #1 or
+// SEC 1 form.
+func parsePrivateKey(key string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(key))
+	if block == nil {
+		return nil, errors.New("Expected a PEM encoded private key, got none.")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to parse private key with the following error: '%s'.",
+			err.Error(),
+		)
+	}
+	signer, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("Expected a signing private key, got: %T.", parsed)
+	}
+	return signer, nil
+}
+
+// privateKeyMatches reports whether the private key belongs to the public key
+// of the certificate.
+func privateKeyMatches(cert *x509.Certificate, key crypto.Signer) bool {
+	public, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && public.Equal(cert.PublicKey)
+}
+
+// certificateSANs returns the subject alternative names of the certificate,
+// DNS names first, followed by IP addresses, email addresses and URIs.
+func certificateSANs(cert *x509.Certificate) []string {
+	sans := []string{}
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+	return sans
+}
+
+// certificateFingerprint returns the hexadecimal SHA-256 fingerprint of the
+// DER encoded certificate.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// certificateDaysRemaining returns the number of whole days until the
+// certificate expires, negative once it has.
+func certificateDaysRemaining(cert *x509.Certificate, now time.Time) int64 {
+	return int64(math.Floor(cert.NotAfter.Sub(now).Hours() / 24))
+}
+
+// orderCertificateChain orders the certificates from the leaf, the first
+// certificate, up to the last issuer found among them, checking the signature
+// of every link. Duplicates are dropped and certificates that aren't part of
+// the chain are returned separately.
+func orderCertificateChain(
+	certs []*x509.Certificate,
+) (
+	[]*x509.Certificate,
+	[]*x509.Certificate,
+) {
+	unique := []*x509.Certificate{}
+	seen := map[string]bool{}
+	for _, cert := range certs {
+		fingerprint := certificateFingerprint(cert)
+		if !seen[fingerprint] {
+			seen[fingerprint] = true
+			unique = append(unique, cert)
+		}
+	}
+	if len(unique) == 0 {
+		return unique, nil
+	}
+
+	chain := []*x509.Certificate{unique[0]}
+	rest := unique[1:]
+	for {
+		last := chain[len(chain)-1]
+		if bytes.Equal(last.RawIssuer, last.RawSubject) {
+			break
+		}
+		next := -1
+		for i, cert := range rest {
+			if bytes.Equal(last.RawIssuer, cert.RawSubject) && last.CheckSignatureFrom(cert) == nil {
+				next = i
+				break
+			}
+		}
+		if next == -1 {
+			break
+		}
+		chain = append(chain, rest[next])
+		rest = append(rest[:next:next], rest[next+1:]...)
+	}
+	return chain, rest
+}
+
+// encodeCertificates returns the certificates PEM encoded one after another.
+func encodeCertificates(certs []*x509.Certificate) string {
+	var out strings.Builder
+	for _, cert := range certs {
+		_ = pem.Encode(&out, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return out.String()
+}
+
+// encodePKCS12 encodes the private key and the chain, leaf first, as PKCS #12
+// protected with the password using AES-256 and PBKDF2. The salts and IVs are
+// derived from the key, the chain and the password rather than random, so the
+// same inputs always give the same file.
+func encodePKCS12(key crypto.Signer, chain []*x509.Certificate, password string) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	for _, cert := range chain {
+		h.Write(cert.Raw)
+	}
+	rand := hkdf.New(sha256.New, append(der, password...), h.Sum(nil), []byte("pkcs12"))
+	return pkcs12.Modern.WithRand(rand).Encode(key, chain[0], chain[1:], password)
+}
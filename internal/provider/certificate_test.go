@@ -0,0 +1,209 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// testCertificate is a generated certificate with its key, to sign others.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// newTestCertificate generates a certificate for the DNS names, signed by
+// the parent or self-signed if it's nil.
+func newTestCertificate(
+	t *testing.T,
+	commonName string,
+	parent *testCertificate,
+	dnsNames ...string,
+) *testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		DNSNames:              dnsNames,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  len(dnsNames) == 0,
+	}
+
+	issuer, signer := template, key
+	if parent != nil {
+		issuer, signer = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{cert: cert, key: key}
+}
+
+func TestOrderCertificateChain(t *testing.T) {
+	root := newTestCertificate(t, "Root", nil)
+	intermediate := newTestCertificate(t, "Intermediate", root)
+	leaf := newTestCertificate(t, "example.com", intermediate, "example.com")
+	// The impostor has the subject of the intermediate, but didn't sign the
+	// leaf.
+	impostor := newTestCertificate(t, "Intermediate", root)
+	unrelated := newTestCertificate(t, "Unrelated", nil)
+
+	names := map[*x509.Certificate]string{
+		root.cert:         "root",
+		intermediate.cert: "intermediate",
+		leaf.cert:         "leaf",
+		impostor.cert:     "impostor",
+		unrelated.cert:    "unrelated",
+	}
+	certs := func(certs ...*testCertificate) []*x509.Certificate {
+		out := []*x509.Certificate{}
+		for _, cert := range certs {
+			out = append(out, cert.cert)
+		}
+		return out
+	}
+	nameAll := func(certs []*x509.Certificate) []string {
+		out := []string{}
+		for _, cert := range certs {
+			out = append(out, names[cert])
+		}
+		return out
+	}
+
+	tests := []struct {
+		name  string
+		certs []*x509.Certificate
+		chain []string
+		rest  []string
+	}{
+		{
+			name:  "ordered",
+			certs: certs(leaf, intermediate, root),
+			chain: []string{"leaf", "intermediate", "root"},
+			rest:  []string{},
+		},
+		{
+			name:  "unordered",
+			certs: certs(leaf, root, intermediate),
+			chain: []string{"leaf", "intermediate", "root"},
+			rest:  []string{},
+		},
+		{
+			name:  "without root",
+			certs: certs(leaf, intermediate),
+			chain: []string{"leaf", "intermediate"},
+			rest:  []string{},
+		},
+		{
+			name:  "leaf only",
+			certs: certs(leaf),
+			chain: []string{"leaf"},
+			rest:  []string{},
+		},
+		{
+			name:  "duplicates",
+			certs: certs(leaf, intermediate, intermediate, root, leaf),
+			chain: []string{"leaf", "intermediate", "root"},
+			rest:  []string{},
+		},
+		{
+			name:  "unrelated",
+			certs: certs(leaf, unrelated, intermediate),
+			chain: []string{"leaf", "intermediate"},
+			rest:  []string{"unrelated"},
+		},
+		{
+			name:  "same subject, other signer",
+			certs: certs(leaf, impostor, intermediate, root),
+			chain: []string{"leaf", "intermediate", "root"},
+			rest:  []string{"impostor"},
+		},
+		{
+			name:  "self-signed first",
+			certs: certs(root, intermediate, leaf),
+			chain: []string{"root"},
+			rest:  []string{"intermediate", "leaf"},
+		},
+		{
+			name:  "empty",
+			certs: certs(),
+			chain: []string{},
+			rest:  []string{},
+		},
+	}
+
+	for _, test := range tests {
+		chain, rest := orderCertificateChain(test.certs)
+		if got := nameAll(chain); !reflect.DeepEqual(got, test.chain) {
+			t.Errorf("%s: chain = %q, want %q", test.name, got, test.chain)
+		}
+		if got := nameAll(rest); !reflect.DeepEqual(got, test.rest) {
+			t.Errorf("%s: rest = %q, want %q", test.name, got, test.rest)
+		}
+	}
+}
+
+func TestEncodePKCS12(t *testing.T) {
+	root := newTestCertificate(t, "Root", nil)
+	leaf := newTestCertificate(t, "example.com", root, "example.com")
+	chain := []*x509.Certificate{leaf.cert, root.cert}
+
+	pfx, err := encodePKCS12(leaf.key, chain, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The same inputs give the same file, so reading it again shows no change.
+	again, err := encodePKCS12(leaf.key, chain, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pfx, again) {
+		t.Error("encodePKCS12() gave different files for the same inputs")
+	}
+	other, err := encodePKCS12(leaf.key, chain, "other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(pfx, other) {
+		t.Error("encodePKCS12() gave the same file for different passwords")
+	}
+
+	key, cert, caCerts, err := pkcs12.DecodeChain(pfx, "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !leaf.key.Equal(key) {
+		t.Error("decoded key differs from the encoded one")
+	}
+	if !cert.Equal(leaf.cert) || len(caCerts) != 1 || !caCerts[0].Equal(root.cert) {
+		t.Errorf("decoded chain %v %v, want %v %v", cert.Subject, caCerts, leaf.cert.Subject, root.cert.Subject)
+	}
+	if _, _, _, err := pkcs12.DecodeChain(pfx, "other"); err == nil {
+		t.Error("decoded the file with the wrong password")
+	}
+}
@@ -73,7 +73,7 @@ func (d *DNSRecrodDataSource) Schema(
 			},
 			"subdomain": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Your subdomain. Requires type to be set.",
+				MarkdownDescription: "Your subdomain, either relative or fully qualified. Requires type to be set.",
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 253),
 					stringvalidator.AlsoRequires(path.Expressions{
@@ -117,7 +117,8 @@ func (d *DNSRecrodDataSource) Schema(
 							Computed: true,
 						},
 						"subdomain": schema.StringAttribute{
-							Computed: true,
+							Computed:            true,
+							MarkdownDescription: "The subdomain relative to the domain, empty for the root domain.",
 						},
 						"type": schema.StringAttribute{
 							Computed: true,
@@ -177,18 +178,21 @@ func (d *DNSRecrodDataSource) Read(
 	domain := model.Domain.ValueString()
 	subdomain := model.Subdomain.ValueString()
 	type_ := model.Type.ValueString()
-	id := model.ID.ValueInt64()
+	var id *int64
+	if !model.ID.IsNull() {
+		id = &[]int64{model.ID.ValueInt64()}[0]
+	}
 
 	var records []*porkbun.DNSRecord
 	var errs []error
 	if type_ != "" {
 		records, errs = d.client.DNSRecordsByTypeName(ctx, domain, type_, subdomain)
 	} else {
-		records, errs = d.client.DNSRecords(ctx, domain, &id)
+		records, errs = d.client.DNSRecords(ctx, domain, id)
 	}
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
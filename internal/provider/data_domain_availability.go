@@ -0,0 +1,186 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainAvailabilityDataSource{}
+
+type DomainAvailabilityDataSource struct {
+	client *porkbun.Client
+}
+
+type DomainAvailabilityDataSourceModel struct {
+	Domain         types.String  `tfsdk:"domain"`
+	Avail          types.Bool    `tfsdk:"avail"`
+	Price          types.Float64 `tfsdk:"price"`
+	RegularPrice   types.Float64 `tfsdk:"regular_price"`
+	RenewalPrice   types.Float64 `tfsdk:"renewal_price"`
+	TransferPrice  types.Float64 `tfsdk:"transfer_price"`
+	Premium        types.Bool    `tfsdk:"premium"`
+	FirstYearPromo types.Bool    `tfsdk:"first_year_promo"`
+	MinDuration    types.Int64   `tfsdk:"min_duration"`
+}
+
+func NewDomainAvailabilityDataSource() datasource.DataSource {
+	return &DomainAvailabilityDataSource{}
+}
+
+func (d *DomainAvailabilityDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_availability"
+}
+
+func (d *DomainAvailabilityDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Check whether a domain is available for registration and what it costs. " +
+			"Porkbun limits how often domains may be checked, so reading many of these at once will be slow.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain to check.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+			},
+			"avail": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the domain is available for registration.",
+			},
+			"price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The registration price for the first year, including any promotion.",
+			},
+			"regular_price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The registration price without any promotion.",
+			},
+			"renewal_price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The renewal price, if returned.",
+			},
+			"transfer_price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The transfer price, if returned.",
+			},
+			"premium": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the domain has premium pricing.",
+			},
+			"first_year_promo": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the first year price is a promotion.",
+			},
+			"min_duration": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The minimum registration duration in years, if returned.",
+			},
+		},
+	}
+}
+
+func (d *DomainAvailabilityDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DomainAvailabilityDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var model DomainAvailabilityDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	availability, err := d.client.CheckDomain(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	model.Avail = types.BoolValue(availability.Avail == "yes")
+	model.Premium = types.BoolValue(availability.Premium == "yes")
+	model.FirstYearPromo = types.BoolValue(availability.FirstYearPromo == "yes")
+	model.Price = optionalPrice(&resp.Diagnostics, "price", availability.Price)
+	model.RegularPrice = optionalPrice(&resp.Diagnostics, "regular price", availability.RegularPrice)
+	model.RenewalPrice = optionalPrice(&resp.Diagnostics, "renewal price", availability.Additional.Renewal.Price)
+	model.TransferPrice = optionalPrice(&resp.Diagnostics, "transfer price", availability.Additional.Transfer.Price)
+
+	if availability.MinDuration == "" {
+		model.MinDuration = types.Int64Null()
+	} else {
+		minDuration, err := availability.MinDuration.Int64()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
+				"Failed to parse minimum duration as an integer with the following error: '%s'.",
+				err.Error(),
+			))
+		}
+		model.MinDuration = types.Int64Value(minDuration)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+// optionalPrice parses the price, returning null if Porkbun didn't return one.
+func optionalPrice(diags *diag.Diagnostics, name string, s string) types.Float64 {
+	if s == "" {
+		return types.Float64Null()
+	}
+	price, err := parsePrice(s)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf(
+			"Failed to parse %s as a number with the following error: '%s'.",
+			name,
+			err.Error(),
+		))
+		return types.Float64Null()
+	}
+	return types.Float64Value(price)
+}
@@ -0,0 +1,176 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainDNSSECRecordsDataSource{}
+
+type DomainDNSSECRecordsDataSource struct {
+	client *porkbun.Client
+}
+
+type DomainDNSSECRecordsDataSourceModel struct {
+	Domain  types.String        `tfsdk:"domain"`
+	Records []dnssecRecordModel `tfsdk:"records"`
+}
+
+type dnssecRecordModel struct {
+	KeyTag           types.Int64  `tfsdk:"key_tag"`
+	Algorithm        types.Int64  `tfsdk:"algorithm"`
+	DigestType       types.Int64  `tfsdk:"digest_type"`
+	Digest           types.String `tfsdk:"digest"`
+	MaxSigLife       types.Int64  `tfsdk:"max_sig_life"`
+	KeyDataFlags     types.Int64  `tfsdk:"key_data_flags"`
+	KeyDataProtocol  types.Int64  `tfsdk:"key_data_protocol"`
+	KeyDataAlgorithm types.Int64  `tfsdk:"key_data_algorithm"`
+	KeyDataPublicKey types.String `tfsdk:"key_data_public_key"`
+}
+
+func NewDomainDNSSECRecordsDataSource() datasource.DataSource {
+	return &DomainDNSSECRecordsDataSource{}
+}
+
+func (d *DomainDNSSECRecordsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_dnssec_records"
+}
+
+func (d *DomainDNSSECRecordsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the DNSSEC DS records listed at the registry for your domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+			},
+			"records": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "An array of DS records for the domain, sorted by key tag.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key_tag": schema.Int64Attribute{
+							Computed: true,
+						},
+						"algorithm": schema.Int64Attribute{
+							Computed: true,
+						},
+						"digest_type": schema.Int64Attribute{
+							Computed: true,
+						},
+						"digest": schema.StringAttribute{
+							Computed: true,
+						},
+						"max_sig_life": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The maximum signature life in seconds, null if it wasn't set.",
+						},
+						"key_data_flags": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The flags of the key data, null if it wasn't set.",
+						},
+						"key_data_protocol": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The protocol of the key data, null if it wasn't set.",
+						},
+						"key_data_algorithm": schema.Int64Attribute{
+							Computed:            true,
+							MarkdownDescription: "The algorithm number of the key data, null if it wasn't set.",
+						},
+						"key_data_public_key": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The base64 encoded public key of the key data, null if it wasn't set.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainDNSSECRecordsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DomainDNSSECRecordsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var model DomainDNSSECRecordsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	records, errs := d.client.DNSSECRecords(ctx, domain)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	model.Records = []dnssecRecordModel{}
+	for _, record := range records {
+		publicKey := types.StringNull()
+		if record.KeyDataPublicKey != "" {
+			publicKey = types.StringValue(record.KeyDataPublicKey)
+		}
+		model.Records = append(model.Records, dnssecRecordModel{
+			KeyTag:           types.Int64Value(record.KeyTag),
+			Algorithm:        types.Int64Value(record.Algorithm),
+			DigestType:       types.Int64Value(record.DigestType),
+			Digest:           types.StringValue(record.Digest),
+			MaxSigLife:       types.Int64PointerValue(record.MaxSigLife),
+			KeyDataFlags:     types.Int64PointerValue(record.KeyDataFlags),
+			KeyDataProtocol:  types.Int64PointerValue(record.KeyDataProtocol),
+			KeyDataAlgorithm: types.Int64PointerValue(record.KeyDataAlgorithm),
+			KeyDataPublicKey: publicKey,
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
@@ -0,0 +1,140 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DomainGlueRecordsDataSource{}
+
+type DomainGlueRecordsDataSource struct {
+	client *porkbun.Client
+}
+
+type DomainGlueRecordsDataSourceModel struct {
+	Domain types.String      `tfsdk:"domain"`
+	Hosts  []glueRecordModel `tfsdk:"hosts"`
+}
+
+type glueRecordModel struct {
+	Host types.String `tfsdk:"host"`
+	IPs  types.List   `tfsdk:"ips"`
+}
+
+func NewDomainGlueRecordsDataSource() datasource.DataSource {
+	return &DomainGlueRecordsDataSource{}
+}
+
+func (d *DomainGlueRecordsDataSource) Metadata(
+	_ context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_glue_records"
+}
+
+func (d *DomainGlueRecordsDataSource) Schema(
+	_ context.Context,
+	_ datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Get the glue records listed at the registry for your domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+			},
+			"hosts": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "An array of glue hosts for the domain.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"host": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The fully qualified host name.",
+						},
+						"ips": schema.ListAttribute{
+							Computed:            true,
+							MarkdownDescription: "An array of IPv4 and IPv6 addresses of the host.",
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *DomainGlueRecordsDataSource) Configure(
+	_ context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = data.Client
+}
+
+func (d *DomainGlueRecordsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var model DomainGlueRecordsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	records, errs := d.client.GlueRecords(ctx, domain)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	model.Hosts = []glueRecordModel{}
+	for _, record := range records {
+		ips, diags := types.ListValueFrom(ctx, types.StringType, record.IPs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		model.Hosts = append(model.Hosts, glueRecordModel{
+			Host: types.StringValue(record.Host),
+			IPs:  ips,
+		})
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
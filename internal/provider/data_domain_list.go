@@ -134,7 +134,7 @@ func (d *DomainListDataSource) Read(
 		domains, errs := d.client.DomainList(ctx, start)
 		if errs != nil && len(errs) != 0 {
 			for _, err := range errs {
-				resp.Diagnostics.AddError("Client Error", err.Error())
+				addClientError(&resp.Diagnostics, err)
 			}
 			break
 		}
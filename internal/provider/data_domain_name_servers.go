@@ -101,7 +101,7 @@ func (d *DomainNameServersDataSource) Read(
 
 	servers, err := d.client.NameServers(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
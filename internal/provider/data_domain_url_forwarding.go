@@ -133,7 +133,7 @@ func (d *DomainURLForwardingDataSource) Read(
 	forwards, errs := d.client.URLForwards(ctx, domain)
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
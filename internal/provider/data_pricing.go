@@ -113,32 +113,32 @@ func (d *PricingDataSource) Read(
 
 	pricing, err := d.client.Pricing(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
 	models := map[string]pricingModel{}
 	for k, v := range pricing {
-		registration, err := strconv.ParseFloat(strings.ReplaceAll(v.Registration, ",", ""), 64)
+		registration, err := parsePrice(v.Registration)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
-				"Failed to parse registration as an integer with the following error: '%s'.",
+				"Failed to parse registration as a number with the following error: '%s'.",
 				err.Error(),
 			))
 			continue
 		}
-		renewal, err := strconv.ParseFloat(strings.ReplaceAll(v.Renewal, ",", ""), 64)
+		renewal, err := parsePrice(v.Renewal)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
-				"Failed to parse renewal as an integer with the following error: '%s'.",
+				"Failed to parse renewal as a number with the following error: '%s'.",
 				err.Error(),
 			))
 			continue
 		}
-		transfer, err := strconv.ParseFloat(strings.ReplaceAll(v.Transfer, ",", ""), 64)
+		transfer, err := parsePrice(v.Transfer)
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
-				"Failed to parse transfer as an integer with the following error: '%s'.",
+				"Failed to parse transfer as a number with the following error: '%s'.",
 				err.Error(),
 			))
 			continue
@@ -160,3 +160,8 @@ func (d *PricingDataSource) Read(
 	model.Pricing = models
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
+
+// parsePrice parses a price as returned by Porkbun, e.g. '1,234.56'.
+func parsePrice(s string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(s, ",", ""), 64)
+}
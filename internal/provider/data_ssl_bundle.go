@@ -5,11 +5,16 @@ package provider
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
@@ -29,8 +34,29 @@ type SSLBundleDataSourceModel struct {
 	CertificateChain        types.String `tfsdk:"certificate_chain"`
 	PublicKey               types.String `tfsdk:"public_key"`
 	PrivateKey              types.String `tfsdk:"private_key"`
+	EncryptPrivateKeyTo     types.String `tfsdk:"encrypt_private_key_to"`
+	PrivateKeyEncrypted     types.String `tfsdk:"private_key_encrypted"`
+	FullChainPEM            types.String `tfsdk:"full_chain_pem"`
+	CombinedPEM             types.String `tfsdk:"combined_pem"`
+	PKCS12Password          types.String `tfsdk:"pkcs12_password"`
+	PKCS12                  types.String `tfsdk:"pkcs12"`
+	ExpiryWarningDays       types.Int64  `tfsdk:"expiry_warning_days"`
+	Subject                 types.String `tfsdk:"subject"`
+	SANs                    types.List   `tfsdk:"sans"`
+	Issuer                  types.String `tfsdk:"issuer"`
+	NotBefore               types.String `tfsdk:"not_before"`
+	NotAfter                types.String `tfsdk:"not_after"`
+	DaysRemaining           types.Int64  `tfsdk:"days_remaining"`
+	Serial                  types.String `tfsdk:"serial"`
+	FingerprintSHA256       types.String `tfsdk:"fingerprint_sha256"`
+	KeyAlgorithm            types.String `tfsdk:"key_algorithm"`
+	PrivateKeyMatches       types.Bool   `tfsdk:"private_key_matches"`
 }
 
+// defaultExpiryWarningDays is the default number of days before expiry a
+// warning is shown.
+const defaultExpiryWarningDays = 30
+
 func NewSSLBundleDataSource() datasource.DataSource {
 	return &SSLBundleDataSource{}
 }
@@ -49,7 +75,9 @@ func (d *SSLBundleDataSource) Schema(
 	resp *datasource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Retrieve the SSL certificate bundle for the domain.",
+		MarkdownDescription: "Retrieve the SSL certificate bundle for the domain, along with details of the certificate. " +
+			"The private key is stored in the state, use the ephemeral resource of the same name " +
+			"with Terraform 1.10 or later to avoid that.",
 		Attributes: map[string]schema.Attribute{
 			"domain": schema.StringAttribute{
 				Required:            true,
@@ -73,7 +101,98 @@ func (d *SSLBundleDataSource) Schema(
 			"private_key": schema.StringAttribute{
 				Computed:            true,
 				Sensitive:           true,
-				MarkdownDescription: "The private key.",
+				MarkdownDescription: "The private key, null if `encrypt_private_key_to` is set.",
+			},
+			"encrypt_private_key_to": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Any value keeps the private key and the outputs containing it out of the state. " +
+					"It used to be the public key to encrypt the private key to.",
+				DeprecationMessage: "The private key is no longer encrypted, as the encryption differed on every read " +
+					"and showed a change on every plan for anything using it. " +
+					"Use the ephemeral porkbun_ssl_bundle resource to pass the private key to write-only arguments instead.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"private_key_encrypted": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Always null.",
+				DeprecationMessage: "The private key is no longer encrypted, as the encryption differed on every read. " +
+					"Use the ephemeral porkbun_ssl_bundle resource to pass the private key to write-only arguments instead.",
+			},
+			"full_chain_pem": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "The certificate followed by its intermediate certificates in order, " +
+					"with the signature of every link verified.",
+			},
+			"combined_pem": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				MarkdownDescription: "The full chain followed by the private key in a single PEM, e.g. for HAProxy. " +
+					"Null if `encrypt_private_key_to` is set.",
+			},
+			"pkcs12_password": schema.StringAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The password to protect `pkcs12` with.",
+			},
+			"pkcs12": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				MarkdownDescription: "The private key and full chain as a base64 encoded PKCS #12 file, " +
+					"protected with `pkcs12_password` using AES-256 and PBKDF2. " +
+					"Null if `pkcs12_password` isn't set or `encrypt_private_key_to` is set. " +
+					"The salts and IVs are derived from the bundle and the password, " +
+					"so the value only changes when either of them does.",
+			},
+			"expiry_warning_days": schema.Int64Attribute{
+				Optional: true,
+				MarkdownDescription: "Show a warning when the certificate expires within this many days. " +
+					"Defaults to 30, 0 disables the warning.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"subject": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The subject of the certificate.",
+			},
+			"sans": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The subject alternative names of the certificate.",
+				ElementType:         types.StringType,
+			},
+			"issuer": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The issuer of the certificate.",
+			},
+			"not_before": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the certificate is valid from, in RFC 3339 format.",
+			},
+			"not_after": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the certificate expires, in RFC 3339 format.",
+			},
+			"days_remaining": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The number of whole days until the certificate expires, negative once it has.",
+			},
+			"serial": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hexadecimal serial number of the certificate.",
+			},
+			"fingerprint_sha256": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hexadecimal SHA-256 fingerprint of the certificate.",
+			},
+			"key_algorithm": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public key algorithm of the certificate, e.g. 'RSA' or 'ECDSA'.",
+			},
+			"private_key_matches": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the private key belongs to the certificate.",
 			},
 		},
 	}
@@ -116,7 +235,7 @@ func (d *SSLBundleDataSource) Read(
 
 	bundle, err := d.client.SSLBundle(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -124,5 +243,113 @@ func (d *SSLBundleDataSource) Read(
 	model.CertificateChain = types.StringValue(bundle.CertificateChain)
 	model.PublicKey = types.StringValue(bundle.PublicKey)
 	model.PrivateKey = types.StringValue(bundle.PrivateKey)
+	model.PrivateKeyEncrypted = types.StringNull()
+	model.CombinedPEM = types.StringNull()
+	model.PKCS12 = types.StringNull()
+	model.SANs = types.ListNull(types.StringType)
+
+	if !model.EncryptPrivateKeyTo.IsNull() {
+		model.PrivateKey = types.StringNull()
+	}
+
+	certs, err := parseCertificates(bundle.CertificateChain)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Certificate Not Parsed", fmt.Sprintf(
+			"The certificate details are unknown, as the certificate chain of '%s' failed to parse: %s",
+			domain,
+			err.Error(),
+		))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+	cert := certs[0]
+
+	sans, diags := types.ListValueFrom(ctx, types.StringType, certificateSANs(cert))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	daysRemaining := certificateDaysRemaining(cert, time.Now())
+	model.Subject = types.StringValue(cert.Subject.String())
+	model.SANs = sans
+	model.Issuer = types.StringValue(cert.Issuer.String())
+	model.NotBefore = types.StringValue(cert.NotBefore.UTC().Format(time.RFC3339))
+	model.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	model.DaysRemaining = types.Int64Value(daysRemaining)
+	model.Serial = types.StringValue(cert.SerialNumber.Text(16))
+	model.FingerprintSHA256 = types.StringValue(certificateFingerprint(cert))
+	model.KeyAlgorithm = types.StringValue(cert.PublicKeyAlgorithm.String())
+
+	key, err := parsePrivateKey(bundle.PrivateKey)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Private Key Not Parsed", err.Error())
+		model.PrivateKeyMatches = types.BoolValue(false)
+	} else {
+		model.PrivateKeyMatches = types.BoolValue(privateKeyMatches(cert, key))
+	}
+
+	// Porkbun may include the intermediate certificates in the chain as well.
+	intermediates, err := parseCertificates(bundle.IntermediateCertificate)
+	if err != nil {
+		intermediates = nil
+	}
+	chain, unused := orderCertificateChain(append(certs, intermediates...))
+	if len(unused) != 0 {
+		resp.Diagnostics.AddWarning("Certificates Not In Chain", fmt.Sprintf(
+			"%d certificate(s) of the bundle of '%s' don't belong to the chain of the certificate "+
+				"and have been left out of full_chain_pem, combined_pem and pkcs12.",
+			len(unused),
+			domain,
+		))
+	}
+	fullChain := encodeCertificates(chain)
+	model.FullChainPEM = types.StringValue(fullChain)
+
+	// Outputs with the private key are only set if it isn't kept out of the
+	// state.
+	if model.EncryptPrivateKeyTo.IsNull() {
+		model.CombinedPEM = types.StringValue(
+			fullChain + strings.TrimRight(bundle.PrivateKey, "\n") + "\n",
+		)
+
+		if !model.PKCS12Password.IsNull() {
+			if key == nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("pkcs12_password"),
+					"PKCS #12 Not Encoded",
+					"The private key failed to parse, so it can't be encoded as PKCS #12.",
+				)
+				return
+			}
+			pfx, err := encodePKCS12(key, chain, model.PKCS12Password.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("pkcs12_password"),
+					"PKCS #12 Not Encoded",
+					fmt.Sprintf(
+						"Failed to encode PKCS #12 with the following error: '%s'.",
+						err.Error(),
+					),
+				)
+				return
+			}
+			model.PKCS12 = types.StringValue(base64.StdEncoding.EncodeToString(pfx))
+		}
+	}
+
+	threshold := int64(defaultExpiryWarningDays)
+	if !model.ExpiryWarningDays.IsNull() {
+		threshold = model.ExpiryWarningDays.ValueInt64()
+	}
+	if threshold > 0 && daysRemaining < threshold {
+		resp.Diagnostics.AddWarning("Certificate Expires Soon", fmt.Sprintf(
+			"The certificate of '%s' expires at %s, in %d day(s).",
+			domain,
+			model.NotAfter.ValueString(),
+			daysRemaining,
+		))
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
@@ -0,0 +1,261 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var hostnameRE = regexp.MustCompile(
+	`^(?i)([a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?\.)*[a-z0-9_]([a-z0-9_-]{0,61}[a-z0-9])?\.?$`,
+)
+
+var caaTagRE = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+var hexRE = regexp.MustCompile(`^[a-fA-F0-9]+$`)
+
+func isHostname(s string) bool {
+	return len(strings.TrimSuffix(s, ".")) <= 253 && hostnameRE.MatchString(s)
+}
+
+func dnsTypeUsesPriority(type_ string) bool {
+	switch strings.ToUpper(type_) {
+	case "MX", "SRV":
+		return true
+	default:
+		return false
+	}
+}
+
+// validateDNSContent checks that the content is well formed for the record
+// type. Porkbun expects SRV content without the priority, i.e. in the form
+// 'weight port target'.
+func validateDNSContent(type_ string, content string) error {
+	switch strings.ToUpper(type_) {
+	case "A":
+		addr, err := netip.ParseAddr(content)
+		if err != nil || !addr.Is4() {
+			return fmt.Errorf("Expected an IPv4 address for an A record, got: '%s'.", content)
+		}
+	case "AAAA":
+		addr, err := netip.ParseAddr(content)
+		if err != nil || !addr.Is6() || addr.Zone() != "" {
+			return fmt.Errorf("Expected an IPv6 address for an AAAA record, got: '%s'.", content)
+		}
+	case "CNAME", "ALIAS", "NS", "MX":
+		if !isHostname(content) {
+			return fmt.Errorf(
+				"Expected a host name for %s record, got: '%s'.",
+				strings.ToUpper(type_),
+				content,
+			)
+		}
+	case "SRV":
+		fields := strings.Fields(content)
+		if len(fields) != 3 {
+			return fmt.Errorf(
+				"Expected SRV content in the form 'weight port target', got: '%s'.",
+				content,
+			)
+		}
+		if !isUint(fields[0], 16) {
+			return fmt.Errorf("Expected SRV weight between 0 and 65535, got: '%s'.", fields[0])
+		}
+		if !isUint(fields[1], 16) {
+			return fmt.Errorf("Expected SRV port between 0 and 65535, got: '%s'.", fields[1])
+		}
+		if fields[2] != "." && !isHostname(fields[2]) {
+			return fmt.Errorf("Expected SRV target to be a host name, got: '%s'.", fields[2])
+		}
+	case "TLSA":
+		fields := strings.Fields(content)
+		if len(fields) != 4 {
+			return fmt.Errorf(
+				"Expected TLSA content in the form 'usage selector matching-type data', got: '%s'.",
+				content,
+			)
+		}
+		if usage, err := strconv.ParseUint(fields[0], 10, 8); err != nil || usage > 3 {
+			return fmt.Errorf("Expected TLSA certificate usage between 0 and 3, got: '%s'.", fields[0])
+		}
+		if selector, err := strconv.ParseUint(fields[1], 10, 8); err != nil || selector > 1 {
+			return fmt.Errorf("Expected TLSA selector of 0 or 1, got: '%s'.", fields[1])
+		}
+		if matching, err := strconv.ParseUint(fields[2], 10, 8); err != nil || matching > 2 {
+			return fmt.Errorf("Expected TLSA matching type between 0 and 2, got: '%s'.", fields[2])
+		}
+		if _, err := hex.DecodeString(fields[3]); err != nil {
+			return fmt.Errorf("Expected TLSA certificate association data to be hexadecimal, got: '%s'.", fields[3])
+		}
+	case "CAA":
+		fields := strings.SplitN(content, " ", 3)
+		if len(fields) != 3 || strings.TrimSpace(fields[2]) == "" {
+			return fmt.Errorf(
+				"Expected CAA content in the form 'flags tag value', got: '%s'.",
+				content,
+			)
+		}
+		if !isUint(fields[0], 8) {
+			return fmt.Errorf("Expected CAA flags between 0 and 255, got: '%s'.", fields[0])
+		}
+		if !caaTagRE.MatchString(fields[1]) {
+			return fmt.Errorf("Expected CAA tag to be alphanumeric, e.g. 'issue', got: '%s'.", fields[1])
+		}
+	}
+	return nil
+}
+
+// validateDNSRecordConfig adds attribute errors for content that is invalid
+// for the record type and for a priority that is missing or not used by it.
+// Unknown values are skipped, they're validated once known.
+func validateDNSRecordConfig(
+	diags *diag.Diagnostics,
+	type_ string,
+	contentPath path.Path,
+	content types.String,
+	priorityPath path.Path,
+	priority types.Int64,
+) {
+	type_ = strings.ToUpper(type_)
+
+	if !content.IsNull() && !content.IsUnknown() {
+		err := validateDNSContent(type_, content.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				contentPath,
+				"Invalid DNS Record Content",
+				err.Error(),
+			)
+		}
+	}
+
+	if dnsTypeUsesPriority(type_) {
+		if priority.IsNull() {
+			diags.AddAttributeError(
+				priorityPath,
+				"Missing DNS Record Priority",
+				fmt.Sprintf("Priority is required for %s records.", type_),
+			)
+		}
+	} else if !priority.IsNull() && !priority.IsUnknown() {
+		diags.AddAttributeError(
+			priorityPath,
+			"Unexpected DNS Record Priority",
+			fmt.Sprintf("Priority is not used by %s records and must not be set.", type_),
+		)
+	}
+}
+
+// canonicalDNSContent returns the content in a canonical form for the record
+// type, so that contents that only differ in the way they're written compare
+// as equal. That is compressed IP addresses, lowercase host names without the
+// trailing dot and TXT content without the quoting. Content that doesn't
+// parse for its type is returned unchanged.
+func canonicalDNSContent(type_ string, content string) string {
+	switch strings.ToUpper(type_) {
+	case "A", "AAAA":
+		addr, err := netip.ParseAddr(content)
+		if err != nil {
+			return content
+		}
+		return addr.String()
+	case "CNAME", "ALIAS", "NS", "MX":
+		return canonicalHostname(content)
+	case "SRV":
+		fields := strings.Fields(content)
+		if len(fields) != 3 {
+			return content
+		}
+		return canonicalUint(fields[0]) + " " +
+			canonicalUint(fields[1]) + " " +
+			canonicalHostname(fields[2])
+	case "TLSA":
+		fields := strings.Fields(content)
+		if len(fields) != 4 {
+			return content
+		}
+		return canonicalUint(fields[0]) + " " +
+			canonicalUint(fields[1]) + " " +
+			canonicalUint(fields[2]) + " " +
+			strings.ToLower(fields[3])
+	case "CAA":
+		fields := strings.SplitN(strings.TrimSpace(content), " ", 3)
+		if len(fields) != 3 {
+			return content
+		}
+		return canonicalUint(fields[0]) + " " +
+			strings.ToLower(fields[1]) + " " +
+			unquoteTXT(strings.TrimSpace(fields[2]))
+	case "TXT":
+		return unquoteTXT(content)
+	default:
+		return content
+	}
+}
+
+func canonicalHostname(s string) string {
+	if s == "." {
+		return s
+	}
+	return strings.ToLower(strings.TrimSuffix(s, "."))
+}
+
+func canonicalUint(s string) string {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatUint(n, 10)
+}
+
+// unquoteTXT joins a sequence of quoted character strings, e.g.
+// '"v=spf1 " "-all"', into a single unquoted string. Content that isn't
+// entirely made of quoted strings is returned unchanged.
+func unquoteTXT(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, `"`) {
+		return s
+	}
+	var out strings.Builder
+	rest := s
+	for rest != "" {
+		if rest[0] != '"' {
+			return s
+		}
+		end := -1
+		for i := 1; i < len(rest); i++ {
+			if rest[i] == '\\' {
+				i++
+				continue
+			}
+			if rest[i] == '"' {
+				end = i
+				break
+			}
+		}
+		if end == -1 {
+			return s
+		}
+		part := rest[1:end]
+		part = strings.ReplaceAll(part, `\"`, `"`)
+		part = strings.ReplaceAll(part, `\\`, `\`)
+		out.WriteString(part)
+		rest = strings.TrimLeft(rest[end+1:], " \t")
+	}
+	return out.String()
+}
+
+func isUint(s string, bitSize int) bool {
+	_, err := strconv.ParseUint(s, 10, bitSize)
+	return err == nil
+}
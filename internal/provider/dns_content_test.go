@@ -0,0 +1,102 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import "testing"
+
+func TestValidateDNSContent(t *testing.T) {
+	tests := []struct {
+		type_   string
+		content string
+		valid   bool
+	}{
+		{"A", "192.0.2.1", true},
+		{"a", "192.0.2.1", true},
+		{"A", "2001:db8::1", false},
+		{"A", "example.com", false},
+		{"AAAA", "2001:DB8::1", true},
+		{"AAAA", "192.0.2.1", false},
+		{"AAAA", "fe80::1%eth0", false},
+		{"CNAME", "example.com.", true},
+		{"CNAME", "_dmarc.example.com", true},
+		{"CNAME", "exa mple.com", false},
+		{"MX", "mail.example.com", true},
+		{"MX", "10 mail.example.com", false},
+		{"SRV", "5 5060 sip.example.com", true},
+		{"SRV", "5 5060 .", true},
+		{"SRV", "10 5 5060 sip.example.com", false},
+		{"SRV", "5 70000 sip.example.com", false},
+		{"TLSA", "3 1 1 0123abcd", true},
+		{"TLSA", "4 1 1 0123abcd", false},
+		{"TLSA", "3 1 1 xyz", false},
+		{"TLSA", "3 1 1", false},
+		{"CAA", `0 issue "letsencrypt.org"`, true},
+		{"CAA", "0 issue", false},
+		{"CAA", `256 issue "letsencrypt.org"`, false},
+		{"CAA", `0 is-sue "letsencrypt.org"`, false},
+		{"TXT", "anything goes", true},
+	}
+
+	for _, test := range tests {
+		err := validateDNSContent(test.type_, test.content)
+		if (err == nil) != test.valid {
+			t.Errorf("validateDNSContent(%q, %q) = %v, want valid: %t", test.type_, test.content, err, test.valid)
+		}
+	}
+}
+
+func TestCanonicalDNSContent(t *testing.T) {
+	tests := []struct {
+		type_ string
+		a     string
+		b     string
+		equal bool
+	}{
+		{"A", "192.0.2.1", "192.0.2.1", true},
+		{"AAAA", "2001:DB8:0:0:0:0:0:1", "2001:db8::1", true},
+		{"AAAA", "2001:db8::1", "2001:db8::2", false},
+		{"CNAME", "Example.COM.", "example.com", true},
+		{"alias", "example.com", "example.net", false},
+		{"MX", "MAIL.example.com.", "mail.example.com", true},
+		{"SRV", "05 5060 SIP.example.com.", "5 5060 sip.example.com", true},
+		{"SRV", "5 5060 sip.example.com", "5 5061 sip.example.com", false},
+		{"TLSA", "3 1 1 ABCDEF", "3 1 1 abcdef", true},
+		{"CAA", `0 ISSUE "letsencrypt.org"`, "0 issue letsencrypt.org", true},
+		{"CAA", `0 issue "letsencrypt.org"`, `0 issuewild "letsencrypt.org"`, false},
+		{"TXT", `"v=spf1 -all"`, "v=spf1 -all", true},
+		{"TXT", `"v=spf1 " "-all"`, "v=spf1 -all", true},
+		{"TXT", "Hello", "hello", false},
+	}
+
+	for _, test := range tests {
+		a := canonicalDNSContent(test.type_, test.a)
+		b := canonicalDNSContent(test.type_, test.b)
+		if (a == b) != test.equal {
+			t.Errorf("canonicalDNSContent(%q, ...) gave %q and %q for %q and %q, want equal: %t",
+				test.type_, a, b, test.a, test.b, test.equal)
+		}
+	}
+}
+
+func TestUnquoteTXT(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{`"quoted"`, "quoted"},
+		{` "padded" `, "padded"},
+		{`"split " "in" " parts"`, "split in parts"},
+		{`"escaped \"quote\" and \\"`, `escaped "quote" and \`},
+		{`"unterminated`, `"unterminated`},
+		{`"quoted" trailing`, `"quoted" trailing`},
+	}
+
+	for _, test := range tests {
+		got := unquoteTXT(test.in)
+		if got != test.want {
+			t.Errorf("unquoteTXT(%q) = %q, want %q", test.in, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/netip"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// delegationTimeout is how long a single SOA query over UDP or TCP may take.
+const delegationTimeout = 5 * time.Second
+
+// errNoResponse marks a query that got no response at all, as opposed to a
+// response that shows the name server isn't authoritative.
+var errNoResponse = errors.New("no response")
+
+// delegationChecker checks that name servers are authoritative for a domain
+// by querying each of them directly for its SOA record.
+type delegationChecker struct {
+	resolver *net.Resolver
+	port     uint16
+	timeout  time.Duration
+}
+
+// newDelegationChecker returns a checker that resolves the name servers with
+// the DNS server at resolverAddr, or the system resolver if it's empty, and
+// queries them on the port.
+func newDelegationChecker(resolverAddr string, port uint16) *delegationChecker {
+	resolver := net.DefaultResolver
+	if resolverAddr != "" {
+		resolver = &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network string, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, network, resolverAddr)
+			},
+		}
+	}
+	return &delegationChecker{
+		resolver: resolver,
+		port:     port,
+		timeout:  delegationTimeout,
+	}
+}
+
+// check returns an error describing why the name server isn't authoritative
+// for the domain. Every address of the name server that responds must answer
+// authoritatively, so a lame or partly configured address fails the check.
+// An address that doesn't respond at all, e.g. an IPv6 address without IPv6
+// connectivity here, is returned as a warning instead, as long as another
+// address answered authoritatively.
+func (c *delegationChecker) check(
+	ctx context.Context,
+	server string,
+	domain string,
+) (
+	[]string,
+	error,
+) {
+	addrs, err := c.resolver.LookupNetIP(ctx, "ip", strings.TrimSuffix(server, "."))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the name server: %w", err)
+	}
+	if len(addrs) == 0 {
+		return nil, errors.New("the name server has no addresses")
+	}
+
+	authoritative := 0
+	lame := false
+	failures := []string{}
+	for _, addr := range addrs {
+		addrPort := netip.AddrPortFrom(addr.Unmap(), c.port)
+		err := c.querySOA(ctx, addrPort, domain)
+		switch {
+		case err == nil:
+			authoritative++
+		case errors.Is(err, errNoResponse):
+			failures = append(failures, addrPort.String()+": "+err.Error())
+		default:
+			lame = true
+			failures = append(failures, addrPort.String()+": "+err.Error())
+		}
+	}
+	if lame || authoritative == 0 {
+		return nil, errors.New(strings.Join(failures, "; "))
+	}
+	return failures, nil
+}
+
+// querySOA queries the address for the SOA record of the domain over UDP,
+// retrying over TCP if the response is truncated or UDP fails, and returns an
+// error unless the response is an authoritative answer with the SOA record.
+func (c *delegationChecker) querySOA(
+	ctx context.Context,
+	addr netip.AddrPort,
+	domain string,
+) error {
+	name, err := dnsmessage.NewName(strings.TrimSuffix(domain, ".") + ".")
+	if err != nil {
+		return fmt.Errorf("invalid domain: %w", err)
+	}
+	id := uint16(rand.Intn(1 << 16))
+	query := dnsmessage.Message{
+		Header: dnsmessage.Header{ID: id},
+		Questions: []dnsmessage.Question{{
+			Name:  name,
+			Type:  dnsmessage.TypeSOA,
+			Class: dnsmessage.ClassINET,
+		}},
+	}
+	packed, err := query.Pack()
+	if err != nil {
+		return fmt.Errorf("failed to pack the query: %w", err)
+	}
+
+	response, err := c.exchange(ctx, "udp", addr, packed)
+	if err != nil || response.Header.Truncated {
+		var tcpErr error
+		response, tcpErr = c.exchange(ctx, "tcp", addr, packed)
+		switch {
+		case tcpErr == nil:
+		case err == nil || errors.Is(err, errNoResponse) && !errors.Is(tcpErr, errNoResponse):
+			return tcpErr
+		case !errors.Is(err, errNoResponse):
+			return err
+		default:
+			// Neither responded, so the address is unreachable.
+			return fmt.Errorf("%w; %w", err, tcpErr)
+		}
+	}
+
+	if response.Header.ID != id {
+		return errors.New("the response ID doesn't match the query")
+	}
+	if response.Header.RCode != dnsmessage.RCodeSuccess {
+		return fmt.Errorf("the response code is %s", strings.TrimPrefix(response.Header.RCode.String(), "RCode"))
+	}
+	if !response.Header.Authoritative {
+		return errors.New("the answer is not authoritative")
+	}
+	for _, answer := range response.Answers {
+		if answer.Header.Type == dnsmessage.TypeSOA &&
+			strings.EqualFold(answer.Header.Name.String(), name.String()) {
+			return nil
+		}
+	}
+	return errors.New("the answer has no SOA record for the domain")
+}
+
+// exchange sends the query over the network and returns the response, or an
+// error wrapping errNoResponse if there was none within the timeout.
+func (c *delegationChecker) exchange(
+	ctx context.Context,
+	network string,
+	addr netip.AddrPort,
+	packed []byte,
+) (
+	*dnsmessage.Message,
+	error,
+) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, network, addr.String())
+	if err != nil {
+		return nil, fmt.Errorf("%w over %s: failed to connect: %w", errNoResponse, strings.ToUpper(network), err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	var buf []byte
+	if network == "tcp" {
+		// Messages over TCP are prefixed with their length.
+		out := binary.BigEndian.AppendUint16(nil, uint16(len(packed)))
+		_, err = conn.Write(append(out, packed...))
+		if err == nil {
+			var length [2]byte
+			_, err = io.ReadFull(conn, length[:])
+			if err == nil {
+				buf = make([]byte, binary.BigEndian.Uint16(length[:]))
+				_, err = io.ReadFull(conn, buf)
+			}
+		}
+	} else {
+		_, err = conn.Write(packed)
+		if err == nil {
+			buf = make([]byte, 65535)
+			var n int
+			n, err = conn.Read(buf)
+			buf = buf[:n]
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w over %s: %w", errNoResponse, strings.ToUpper(network), err)
+	}
+
+	var response dnsmessage.Message
+	err = response.Unpack(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the response: %w", err)
+	}
+	return &response, nil
+}
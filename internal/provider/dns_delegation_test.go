@@ -0,0 +1,296 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// Behaviours of the addresses of the test DNS server for SOA queries.
+const (
+	soaAuthoritative    = "authoritative"
+	soaNotAuthoritative = "not authoritative"
+	soaWrongName        = "wrong name"
+	soaTruncated        = "truncated"
+	soaUDPBlocked       = "udp blocked"
+	soaDown             = "down"
+)
+
+// testDNSServer answers A and AAAA queries for hosts and SOA queries according
+// to the behaviour of the loopback address they were sent to, all on the same
+// port over UDP and TCP.
+type testDNSServer struct {
+	port      uint16
+	hosts     map[string][]netip.Addr
+	behaviour map[netip.Addr]string
+}
+
+func newTestDNSServer(
+	t *testing.T,
+	hosts map[string][]netip.Addr,
+	behaviour map[netip.Addr]string,
+) *testDNSServer {
+	t.Helper()
+
+	s := &testDNSServer{hosts: hosts, behaviour: behaviour}
+	addrs := []netip.Addr{}
+	for addr := range behaviour {
+		addrs = append(addrs, addr)
+	}
+
+	// Every address needs the same port, so retry with another one if it's
+	// already taken on one of them.
+	for range 10 {
+		closers, err := s.listen(addrs)
+		if err == nil {
+			t.Cleanup(func() {
+				for _, closer := range closers {
+					closer.Close()
+				}
+			})
+			return s
+		}
+		for _, closer := range closers {
+			closer.Close()
+		}
+	}
+	t.Skip("failed to listen on the same port on every loopback address")
+	return nil
+}
+
+func (s *testDNSServer) listen(addrs []netip.Addr) ([]io.Closer, error) {
+	closers := []io.Closer{}
+	s.port = 0
+	for _, addr := range addrs {
+		udp, err := net.ListenUDP("udp", net.UDPAddrFromAddrPort(netip.AddrPortFrom(addr, s.port)))
+		if err != nil {
+			return closers, err
+		}
+		closers = append(closers, udp)
+		s.port = uint16(udp.LocalAddr().(*net.UDPAddr).Port)
+
+		tcp, err := net.ListenTCP("tcp", net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, s.port)))
+		if err != nil {
+			return closers, err
+		}
+		closers = append(closers, tcp)
+
+		go s.serveUDP(addr, udp)
+		go s.serveTCP(addr, tcp)
+	}
+	return closers, nil
+}
+
+func (s *testDNSServer) serveUDP(addr netip.Addr, conn *net.UDPConn) {
+	buf := make([]byte, 65535)
+	for {
+		n, from, err := conn.ReadFromUDPAddrPort(buf)
+		if err != nil {
+			return
+		}
+		response := s.answer(addr, "udp", buf[:n])
+		if response != nil {
+			_, _ = conn.WriteToUDPAddrPort(response, from)
+		}
+	}
+}
+
+func (s *testDNSServer) serveTCP(addr netip.Addr, listener *net.TCPListener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			var length [2]byte
+			if _, err := io.ReadFull(conn, length[:]); err != nil {
+				return
+			}
+			query := make([]byte, binary.BigEndian.Uint16(length[:]))
+			if _, err := io.ReadFull(conn, query); err != nil {
+				return
+			}
+			response := s.answer(addr, "tcp", query)
+			if response != nil {
+				out := binary.BigEndian.AppendUint16(nil, uint16(len(response)))
+				_, _ = conn.Write(append(out, response...))
+			}
+		}()
+	}
+}
+
+// answer returns the packed response to the query, or nil to not respond.
+func (s *testDNSServer) answer(addr netip.Addr, network string, packed []byte) []byte {
+	var query dnsmessage.Message
+	if err := query.Unpack(packed); err != nil || len(query.Questions) != 1 {
+		return nil
+	}
+	question := query.Questions[0]
+	response := dnsmessage.Message{
+		Header: dnsmessage.Header{
+			ID:            query.Header.ID,
+			Response:      true,
+			Authoritative: true,
+		},
+		Questions: query.Questions,
+	}
+
+	switch question.Type {
+	case dnsmessage.TypeA, dnsmessage.TypeAAAA:
+		addrs, ok := s.hosts[strings.ToLower(question.Name.String())]
+		if !ok {
+			response.Header.RCode = dnsmessage.RCodeNameError
+		}
+		for _, host := range addrs {
+			header := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: 60}
+			switch {
+			case host.Is4() && question.Type == dnsmessage.TypeA:
+				header.Type = dnsmessage.TypeA
+				response.Answers = append(response.Answers, dnsmessage.Resource{
+					Header: header,
+					Body:   &dnsmessage.AResource{A: host.As4()},
+				})
+			case host.Is6() && question.Type == dnsmessage.TypeAAAA:
+				header.Type = dnsmessage.TypeAAAA
+				response.Answers = append(response.Answers, dnsmessage.Resource{
+					Header: header,
+					Body:   &dnsmessage.AAAAResource{AAAA: host.As16()},
+				})
+			}
+		}
+	case dnsmessage.TypeSOA:
+		name := question.Name
+		switch s.behaviour[addr] {
+		case soaNotAuthoritative:
+			response.Header.Authoritative = false
+		case soaWrongName:
+			name = dnsmessage.MustNewName("example.net.")
+		case soaTruncated:
+			if network == "udp" {
+				response.Header.Truncated = true
+				return mustPack(response)
+			}
+		case soaUDPBlocked:
+			if network == "udp" {
+				return nil
+			}
+		case soaDown:
+			return nil
+		}
+		response.Answers = append(response.Answers, dnsmessage.Resource{
+			Header: dnsmessage.ResourceHeader{
+				Name:  name,
+				Type:  dnsmessage.TypeSOA,
+				Class: dnsmessage.ClassINET,
+				TTL:   60,
+			},
+			Body: &dnsmessage.SOAResource{
+				NS:     dnsmessage.MustNewName("ns1.example.com."),
+				MBox:   dnsmessage.MustNewName("hostmaster.example.com."),
+				Serial: 1,
+				MinTTL: 60,
+			},
+		})
+	default:
+		response.Header.RCode = dnsmessage.RCodeNotImplemented
+	}
+	return mustPack(response)
+}
+
+func mustPack(message dnsmessage.Message) []byte {
+	packed, err := message.Pack()
+	if err != nil {
+		panic(err)
+	}
+	return packed
+}
+
+func TestDelegationCheck(t *testing.T) {
+	addr := func(last byte) netip.Addr {
+		return netip.AddrFrom4([4]byte{127, 0, 0, last})
+	}
+	server := newTestDNSServer(
+		t,
+		map[string][]netip.Addr{
+			"ns-authoritative.test.":     {addr(1)},
+			"ns-not-authoritative.test.": {addr(2)},
+			"ns-wrong-name.test.":        {addr(3)},
+			"ns-truncated.test.":         {addr(4)},
+			"ns-udp-blocked.test.":       {addr(5)},
+			"ns-down.test.":              {addr(6)},
+			"ns-partly-lame.test.":       {addr(1), addr(2)},
+			"ns-partly-down.test.":       {addr(1), addr(6)},
+			"ns-no-addresses.test.":      {},
+		},
+		map[netip.Addr]string{
+			addr(1): soaAuthoritative,
+			addr(2): soaNotAuthoritative,
+			addr(3): soaWrongName,
+			addr(4): soaTruncated,
+			addr(5): soaUDPBlocked,
+			addr(6): soaDown,
+		},
+	)
+
+	checker := newDelegationChecker(netip.AddrPortFrom(addr(1), server.port).String(), server.port)
+	checker.timeout = 200 * time.Millisecond
+
+	tests := []struct {
+		server      string
+		err         string
+		unreachable int
+	}{
+		{server: "ns-authoritative.test"},
+		{server: "NS-Authoritative.test."},
+		{server: "ns-not-authoritative.test", err: "the answer is not authoritative"},
+		{server: "ns-wrong-name.test", err: "the answer has no SOA record for the domain"},
+		{server: "ns-truncated.test"},
+		{server: "ns-udp-blocked.test"},
+		{server: "ns-down.test", err: "no response over UDP"},
+		{server: "ns-partly-lame.test", err: "the answer is not authoritative"},
+		{server: "ns-partly-down.test", unreachable: 1},
+		{server: "ns-no-addresses.test", err: "failed to resolve the name server"},
+		{server: "ns-unknown.test", err: "failed to resolve the name server"},
+	}
+
+	for _, test := range tests {
+		unreachable, err := checker.check(context.Background(), test.server, "example.com")
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("check(%q) = %v, want no error", test.server, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("check(%q) = %v, want an error containing %q", test.server, err, test.err)
+		case len(unreachable) != test.unreachable:
+			t.Errorf("check(%q) reported unreachable addresses %q, want %d", test.server, unreachable, test.unreachable)
+		}
+	}
+}
+
+func TestDelegationQuerySOAUnreachable(t *testing.T) {
+	// Nothing listens on the port, so neither UDP nor TCP gets a response.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().(*net.TCPAddr).AddrPort()
+	listener.Close()
+
+	checker := newDelegationChecker("", addr.Port())
+	checker.timeout = 200 * time.Millisecond
+	err = checker.querySOA(context.Background(), addr, "example.com")
+	if !errors.Is(err, errNoResponse) {
+		t.Errorf("querySOA() = %v, want an error wrapping %v", err, errNoResponse)
+	}
+}
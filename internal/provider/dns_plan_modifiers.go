@@ -0,0 +1,169 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementations satisfy the expected interfaces.
+var _ planmodifier.String = equalFoldModifier{}
+var _ planmodifier.String = dnsContentModifier{}
+var _ planmodifier.Set = recordSetValuesModifier{}
+
+// equalFold returns a plan modifier that keeps the value in the state when
+// the planned value only differs from it in letter case, e.g. a record type
+// that Porkbun stores in upper case.
+func equalFold() planmodifier.String {
+	return equalFoldModifier{}
+}
+
+type equalFoldModifier struct{}
+
+func (m equalFoldModifier) Description(_ context.Context) string {
+	return "Values that only differ in letter case are not reported as changes."
+}
+
+func (m equalFoldModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m equalFoldModifier) PlanModifyString(
+	_ context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+	if strings.EqualFold(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// dnsContentEquality returns a plan modifier that keeps the content in the
+// state when the planned content only differs from it in the way it's
+// written for the record type at typePath, see canonicalDNSContent. This
+// keeps the plan clean when the configuration is written differently from
+// the form Porkbun stores, e.g. right after import.
+func dnsContentEquality(typePath path.Path) planmodifier.String {
+	return dnsContentModifier{typePath: typePath}
+}
+
+type dnsContentModifier struct {
+	typePath path.Path
+}
+
+func (m dnsContentModifier) Description(_ context.Context) string {
+	return "Content that only differs in the way it's written for the record type is not reported as a change."
+}
+
+func (m dnsContentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m dnsContentModifier) PlanModifyString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *planmodifier.StringResponse,
+) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	var planType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.typePath, &planType)...)
+	var stateType types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.typePath, &stateType)...)
+	if resp.Diagnostics.HasError() || planType.IsUnknown() {
+		return
+	}
+
+	// Content of a different type is a real change, even if it's written
+	// the same way.
+	type_ := planType.ValueString()
+	if !strings.EqualFold(type_, stateType.ValueString()) {
+		return
+	}
+	if canonicalDNSContent(type_, req.PlanValue.ValueString()) ==
+		canonicalDNSContent(type_, req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// recordSetValuesEquality returns a plan modifier that keeps the values of a
+// record set in the state when every planned value only differs from one of
+// them in the way its content is written for the record type at typePath, see
+// canonicalDNSContent.
+func recordSetValuesEquality(typePath path.Path) planmodifier.Set {
+	return recordSetValuesModifier{typePath: typePath}
+}
+
+type recordSetValuesModifier struct {
+	typePath path.Path
+}
+
+func (m recordSetValuesModifier) Description(_ context.Context) string {
+	return "Values whose content only differs in the way it's written for the record type are not reported as changes."
+}
+
+func (m recordSetValuesModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m recordSetValuesModifier) PlanModifySet(
+	ctx context.Context,
+	req planmodifier.SetRequest,
+	resp *planmodifier.SetResponse,
+) {
+	if req.StateValue.IsNull() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() ||
+		len(req.PlanValue.Elements()) != len(req.StateValue.Elements()) {
+		return
+	}
+
+	var planType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, m.typePath, &planType)...)
+	var stateType types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, m.typePath, &stateType)...)
+	if resp.Diagnostics.HasError() || planType.IsUnknown() {
+		return
+	}
+	type_ := planType.ValueString()
+	if !strings.EqualFold(type_, stateType.ValueString()) {
+		return
+	}
+
+	var planned []recordSetValueModel
+	resp.Diagnostics.Append(req.PlanValue.ElementsAs(ctx, &planned, false)...)
+	var prior []recordSetValueModel
+	resp.Diagnostics.Append(req.StateValue.ElementsAs(ctx, &prior, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every planned value has to match a distinct value in the state.
+	matched := make([]bool, len(prior))
+	for _, value := range planned {
+		if value.Content.IsUnknown() || value.Priority.IsUnknown() {
+			return
+		}
+		found := false
+		for i, p := range prior {
+			if !matched[i] && sameRecordSetValue(type_, value, p) {
+				matched[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+	}
+	resp.PlanValue = req.StateValue
+}
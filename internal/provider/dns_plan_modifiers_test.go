@@ -0,0 +1,204 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDNSContentEquality(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&DNSRecordResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	tests := []struct {
+		name         string
+		stateType    string
+		stateContent types.String
+		planType     types.String
+		planContent  types.String
+		want         types.String
+	}{
+		{"same", "A", types.StringValue("192.0.2.1"), types.StringValue("A"), types.StringValue("192.0.2.1"), types.StringValue("192.0.2.1")},
+		{"compressed IPv6", "AAAA", types.StringValue("2001:db8::1"), types.StringValue("aaaa"), types.StringValue("2001:DB8::1"), types.StringValue("2001:db8::1")},
+		{"trailing dot", "CNAME", types.StringValue("foo.com"), types.StringValue("CNAME"), types.StringValue("FOO.com."), types.StringValue("foo.com")},
+		{"TXT quoting", "TXT", types.StringValue("v=spf1 -all"), types.StringValue("TXT"), types.StringValue(`"v=spf1 -all"`), types.StringValue("v=spf1 -all")},
+		{"changed content", "A", types.StringValue("192.0.2.1"), types.StringValue("A"), types.StringValue("192.0.2.2"), types.StringValue("192.0.2.2")},
+		{"changed type", "CNAME", types.StringValue("foo.com"), types.StringValue("ALIAS"), types.StringValue("FOO.com."), types.StringValue("FOO.com.")},
+		{"unknown type", "CNAME", types.StringValue("foo.com"), types.StringUnknown(), types.StringValue("FOO.com."), types.StringValue("FOO.com.")},
+		{"unknown content", "CNAME", types.StringValue("foo.com"), types.StringValue("CNAME"), types.StringUnknown(), types.StringUnknown()},
+		{"create", "", types.StringNull(), types.StringValue("CNAME"), types.StringValue("FOO.com."), types.StringValue("FOO.com.")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: s, Raw: null}
+			if !test.stateContent.IsNull() {
+				diags := state.Set(ctx, &DNSRecordResourceModel{
+					ID:       types.Int64Value(1),
+					Domain:   types.StringValue("example.com"),
+					Type:     types.StringValue(test.stateType),
+					Content:  test.stateContent,
+					TTL:      types.Int64Value(600),
+					Priority: types.Int64Null(),
+				})
+				if diags.HasError() {
+					t.Fatal(diags)
+				}
+			}
+			plan := tfsdk.Plan{Schema: s, Raw: null}
+			diags := plan.Set(ctx, &DNSRecordResourceModel{
+				ID:       types.Int64Unknown(),
+				Domain:   types.StringValue("example.com"),
+				Type:     test.planType,
+				Content:  test.planContent,
+				TTL:      types.Int64Value(600),
+				Priority: types.Int64Null(),
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			req := planmodifier.StringRequest{
+				Path:       path.Root("content"),
+				State:      state,
+				StateValue: test.stateContent,
+				Plan:       plan,
+				PlanValue:  test.planContent,
+			}
+			resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+			dnsContentEquality(path.Root("type")).PlanModifyString(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if !resp.PlanValue.Equal(test.want) {
+				t.Fatalf("got planned content %s, want %s", resp.PlanValue, test.want)
+			}
+		})
+	}
+}
+
+func TestEqualFold(t *testing.T) {
+	tests := []struct {
+		state types.String
+		plan  types.String
+		want  types.String
+	}{
+		{types.StringValue("A"), types.StringValue("a"), types.StringValue("A")},
+		{types.StringValue("A"), types.StringValue("AAAA"), types.StringValue("AAAA")},
+		{types.StringNull(), types.StringValue("a"), types.StringValue("a")},
+		{types.StringValue("A"), types.StringUnknown(), types.StringUnknown()},
+	}
+
+	for _, test := range tests {
+		req := planmodifier.StringRequest{StateValue: test.state, PlanValue: test.plan}
+		resp := &planmodifier.StringResponse{PlanValue: req.PlanValue}
+		equalFold().PlanModifyString(context.Background(), req, resp)
+		if !resp.PlanValue.Equal(test.want) {
+			t.Errorf("state %s and plan %s gave %s, want %s", test.state, test.plan, resp.PlanValue, test.want)
+		}
+	}
+}
+
+func TestRecordSetValuesEquality(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&DNSRecordSetResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	value := func(content types.String, priority types.Int64) recordSetValueModel {
+		return recordSetValueModel{Content: content, Priority: priority}
+	}
+	mx := func(content string, priority int64) recordSetValueModel {
+		return value(types.StringValue(content), types.Int64Value(priority))
+	}
+	txt := func(content string) recordSetValueModel {
+		return value(types.StringValue(content), types.Int64Null())
+	}
+
+	tests := []struct {
+		name      string
+		stateType string
+		state     []recordSetValueModel
+		planType  types.String
+		plan      []recordSetValueModel
+		kept      bool
+	}{
+		{"same", "TXT", []recordSetValueModel{txt("a"), txt("b")}, types.StringValue("TXT"), []recordSetValueModel{txt("b"), txt("a")}, true},
+		{"TXT quoting", "TXT", []recordSetValueModel{txt("v=spf1 -all")}, types.StringValue("txt"), []recordSetValueModel{txt(`"v=spf1 -all"`)}, true},
+		{"trailing dots", "MX", []recordSetValueModel{mx("mx1.example.net", 10), mx("mx2.example.net", 20)}, types.StringValue("MX"), []recordSetValueModel{mx("MX2.example.net.", 20), mx("mx1.example.net.", 10)}, true},
+		{"changed priority", "MX", []recordSetValueModel{mx("mx1.example.net", 10)}, types.StringValue("MX"), []recordSetValueModel{mx("mx1.example.net.", 20)}, false},
+		{"changed content", "TXT", []recordSetValueModel{txt("a"), txt("b")}, types.StringValue("TXT"), []recordSetValueModel{txt("a"), txt(`"c"`)}, false},
+		{"duplicate match", "TXT", []recordSetValueModel{txt("a"), txt("b")}, types.StringValue("TXT"), []recordSetValueModel{txt("a"), txt(`"a"`)}, false},
+		{"added", "TXT", []recordSetValueModel{txt("a")}, types.StringValue("TXT"), []recordSetValueModel{txt(`"a"`), txt("b")}, false},
+		{"changed type", "CNAME", []recordSetValueModel{txt("foo.com")}, types.StringValue("ALIAS"), []recordSetValueModel{txt("foo.com.")}, false},
+		{"unknown type", "CNAME", []recordSetValueModel{txt("foo.com")}, types.StringUnknown(), []recordSetValueModel{txt("foo.com.")}, false},
+		{"unknown content", "TXT", []recordSetValueModel{txt("a")}, types.StringValue("TXT"), []recordSetValueModel{value(types.StringUnknown(), types.Int64Null())}, false},
+		{"create", "", nil, types.StringValue("TXT"), []recordSetValueModel{txt(`"a"`)}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: s, Raw: null}
+			if test.state != nil {
+				diags := state.Set(ctx, &DNSRecordSetResourceModel{
+					ID:        types.StringValue("example.com TXT"),
+					Domain:    types.StringValue("example.com"),
+					Subdomain: types.StringValue(""),
+					Type:      types.StringValue(test.stateType),
+					TTL:       types.Int64Value(600),
+					Values:    test.state,
+				})
+				if diags.HasError() {
+					t.Fatal(diags)
+				}
+			}
+			plan := tfsdk.Plan{Schema: s, Raw: null}
+			diags := plan.Set(ctx, &DNSRecordSetResourceModel{
+				ID:        types.StringUnknown(),
+				Domain:    types.StringValue("example.com"),
+				Subdomain: types.StringValue(""),
+				Type:      test.planType,
+				TTL:       types.Int64Value(600),
+				Values:    test.plan,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			req := planmodifier.SetRequest{Path: path.Root("values"), State: state, Plan: plan}
+			diags.Append(state.GetAttribute(ctx, req.Path, &req.StateValue)...)
+			diags.Append(plan.GetAttribute(ctx, req.Path, &req.PlanValue)...)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			resp := &planmodifier.SetResponse{PlanValue: req.PlanValue}
+			recordSetValuesEquality(path.Root("type")).PlanModifySet(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			want := req.PlanValue
+			if test.kept {
+				want = req.StateValue
+			}
+			if !resp.PlanValue.Equal(want) {
+				t.Fatalf("got planned values %s, want %s", resp.PlanValue, want)
+			}
+		})
+	}
+}
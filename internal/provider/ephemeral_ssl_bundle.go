@@ -0,0 +1,130 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ ephemeral.EphemeralResource = &SSLBundleEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &SSLBundleEphemeralResource{}
+
+type SSLBundleEphemeralResource struct {
+	client *porkbun.Client
+}
+
+type SSLBundleEphemeralResourceModel struct {
+	Domain                  types.String `tfsdk:"domain"`
+	IntermediateCertificate types.String `tfsdk:"intermediate_certificate"`
+	CertificateChain        types.String `tfsdk:"certificate_chain"`
+	PublicKey               types.String `tfsdk:"public_key"`
+	PrivateKey              types.String `tfsdk:"private_key"`
+}
+
+func NewSSLBundleEphemeralResource() ephemeral.EphemeralResource {
+	return &SSLBundleEphemeralResource{}
+}
+
+func (e *SSLBundleEphemeralResource) Metadata(
+	_ context.Context,
+	req ephemeral.MetadataRequest,
+	resp *ephemeral.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_bundle"
+}
+
+func (e *SSLBundleEphemeralResource) Schema(
+	_ context.Context,
+	_ ephemeral.SchemaRequest,
+	resp *ephemeral.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Retrieve the SSL certificate bundle for the domain without storing it in the state or plan. " +
+			"Requires Terraform 1.10 or later, use the data source of the same name with older versions.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+			},
+			"intermediate_certificate": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The intermediate certificate.",
+			},
+			"certificate_chain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The complete certificate chain.",
+			},
+			"public_key": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The public key.",
+			},
+			"private_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The private key.",
+			},
+		},
+	}
+}
+
+func (e *SSLBundleEphemeralResource) Configure(
+	_ context.Context,
+	req ephemeral.ConfigureRequest,
+	resp *ephemeral.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	e.client = data.Client
+}
+
+func (e *SSLBundleEphemeralResource) Open(
+	ctx context.Context,
+	req ephemeral.OpenRequest,
+	resp *ephemeral.OpenResponse,
+) {
+	var model SSLBundleEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	bundle, err := e.client.SSLBundle(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	model.IntermediateCertificate = types.StringValue(bundle.IntermediateCertificate)
+	model.CertificateChain = types.StringValue(bundle.CertificateChain)
+	model.PublicKey = types.StringValue(bundle.PublicKey)
+	model.PrivateKey = types.StringValue(bundle.PrivateKey)
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &model)...)
+}
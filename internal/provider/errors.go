@@ -0,0 +1,35 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// addClientError adds an error diagnostic for an error returned by the
+// Porkbun client, with a summary and hint matching the kind of failure.
+func addClientError(diags *diag.Diagnostics, err error) {
+	switch {
+	case errors.Is(err, porkbun.ErrUnauthorized):
+		diags.AddError("Porkbun Authorization Error", err.Error()+"\n\n"+
+			"Make sure the API keys are valid and that API access is enabled for the domain.")
+	case errors.Is(err, porkbun.ErrDomainNotFound):
+		diags.AddError("Porkbun Domain Not Found", err.Error()+"\n\n"+
+			"Make sure the domain is spelled correctly and belongs to the account of the API keys.")
+	case errors.Is(err, porkbun.ErrNotFound):
+		diags.AddError("Porkbun Object Not Found", err.Error())
+	case errors.Is(err, porkbun.ErrRateLimited):
+		diags.AddError("Porkbun Rate Limit Exceeded", err.Error()+"\n\n"+
+			"Consider increasing max_retries or max_backoff in the provider configuration.")
+	case errors.Is(err, porkbun.ErrServer):
+		diags.AddError("Porkbun Server Error", err.Error()+"\n\n"+
+			"This is likely a temporary issue on Porkbun's side, please try again later.")
+	default:
+		diags.AddError("Client Error", err.Error())
+	}
+}
@@ -5,12 +5,15 @@ package provider
 
 import (
 	"context"
-	"net/http"
+	"net/url"
 	"os"
 	"regexp"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -23,6 +26,7 @@ import (
 
 // Ensure PorkbunProvider satisfies various provider interfaces.
 var _ provider.Provider = &PorkbunProvider{}
+var _ provider.ProviderWithEphemeralResources = &PorkbunProvider{}
 
 var apiKeyRE = regexp.MustCompile(`^pk1_[0-9a-f]{64}$`)
 var secretAPIKeyRE = regexp.MustCompile(`^sk1_[0-9a-f]{64}$`)
@@ -32,10 +36,17 @@ type PorkbunProvider struct {
 }
 
 type PorkbunProviderModel struct {
-	APIKey            types.String `tfsdk:"api_key"`
-	SecretAPIKey      types.String `tfsdk:"secret_api_key"`
-	ForceIPv4         types.Bool   `tfsdk:"force_ipv4"`
-	DeleteNameServers types.Bool   `tfsdk:"delete_name_servers"`
+	APIKey             types.String `tfsdk:"api_key"`
+	SecretAPIKey       types.String `tfsdk:"secret_api_key"`
+	ForceIPv4          types.Bool   `tfsdk:"force_ipv4"`
+	DeleteNameServers  types.Bool   `tfsdk:"delete_name_servers"`
+	MaxRetries         types.Int64  `tfsdk:"max_retries"`
+	MaxBackoff         types.Int64  `tfsdk:"max_backoff"`
+	Endpoint           types.String `tfsdk:"endpoint"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
+	HTTPProxy          types.String `tfsdk:"http_proxy"`
+	CABundle           types.String `tfsdk:"ca_bundle"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
 }
 
 type PorkbunProviderData struct {
@@ -80,9 +91,61 @@ func (p *PorkbunProvider) Schema(
 				MarkdownDescription: "Force the use of IPv4 via the dedicated IPv4 hostname *api-ipv4.porkbun.com* instead of the default *porkbun.com*.",
 				Optional:            true,
 			},
-			"delete_name_servers": schema.BoolAttribute{
-				MarkdownDescription: "Delete name servers on terraform destroy by updating them to an empty list. Disabled by default.",
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the API, e.g. *https://porkbun.com/api/json/v3*. " +
+					"Useful for pointing the provider at a local stand-in or an egress proxy. " +
+					"Overrides PORKBUN_ENDPOINT environment variable. Conflicts with force_ipv4.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.Expressions{
+						path.MatchRoot("force_ipv4"),
+					}...),
+				},
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: "Timeout in seconds for a single API request, including reading the response. Defaults to 60.",
 				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3600),
+				},
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy to send API requests through. " +
+					"Defaults to the proxy configured by HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables.",
+				Optional: true,
+			},
+			"ca_bundle": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificates to trust in addition to the system ones, " +
+					"e.g. for a TLS intercepting corporate proxy.",
+				Optional: true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip TLS certificate verification. Only meant for test setups. Disabled by default.",
+				Optional:            true,
+			},
+			"delete_name_servers": schema.BoolAttribute{
+				MarkdownDescription: "Deprecated, use `on_destroy` of `porkbun_domain_name_servers` instead. " +
+					"Restore Porkbun's default name servers on terraform destroy, " +
+					"i.e. `on_destroy = \"porkbun_default\"` for every `porkbun_domain_name_servers` that doesn't set it. Disabled by default.",
+				Optional: true,
+				DeprecationMessage: "Use the on_destroy attribute of porkbun_domain_name_servers instead. " +
+					"Until it's removed, true sets on_destroy to \"porkbun_default\" where on_destroy isn't configured.",
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of times a failed API request is retried. " +
+					"Only timeouts, connection errors, HTTP 429 and 5xx responses and rate limit errors are retried. Defaults to 3.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(0, 100),
+				},
+			},
+			"max_backoff": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of seconds to wait between retries. " +
+					"The wait time grows exponentially with random jitter up to this limit. Defaults to 30.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, 3600),
+				},
 			},
 		},
 	}
@@ -127,15 +190,77 @@ func (p *PorkbunProvider) Configure(
 		return
 	}
 
-	forceIPv4 := model.ForceIPv4.ValueBool()
+	baseURL := porkbun.DefaultURL(model.ForceIPv4.ValueBool())
+	endpoint := os.Getenv("PORKBUN_ENDPOINT")
+	if !model.Endpoint.IsNull() {
+		endpoint = model.Endpoint.ValueString()
+	}
+	if endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid Porkbun API Endpoint",
+				"Endpoint must be an absolute http or https URL, got: "+endpoint,
+			)
+			return
+		}
+		baseURL = u
+	}
+
+	var proxy *url.URL
+	if !model.HTTPProxy.IsNull() {
+		u, err := url.Parse(model.HTTPProxy.ValueString())
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("http_proxy"),
+				"Invalid HTTP Proxy",
+				"HTTP proxy must be an absolute URL, got: "+model.HTTPProxy.ValueString(),
+			)
+			return
+		}
+		proxy = u
+	}
+
+	timeout := 60 * time.Second
+	if !model.RequestTimeout.IsNull() {
+		timeout = time.Duration(model.RequestTimeout.ValueInt64()) * time.Second
+	}
+
+	httpClient, err := newHTTPClient(
+		timeout,
+		proxy,
+		model.CABundle.ValueString(),
+		model.InsecureSkipVerify.ValueBool(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_bundle"),
+			"Invalid CA Bundle",
+			err.Error(),
+		)
+		return
+	}
+
 	deleteNameServers := model.DeleteNameServers.ValueBool()
 
-	client := porkbun.NewClient(http.DefaultClient, apiKey, secretAPIKey, forceIPv4)
+	retry := porkbun.DefaultRetryPolicy()
+	if !model.MaxRetries.IsNull() {
+		retry.MaxRetries = model.MaxRetries.ValueInt64()
+	}
+	if !model.MaxBackoff.IsNull() {
+		retry.MaxBackoff = time.Duration(model.MaxBackoff.ValueInt64()) * time.Second
+	}
+	if retry.MinBackoff > retry.MaxBackoff {
+		retry.MinBackoff = retry.MaxBackoff
+	}
+
+	client := porkbun.NewClient(httpClient, baseURL, apiKey, secretAPIKey, retry)
 
 	// Try authenticating with supplied keys.
-	_, err := client.Ping(ctx)
+	_, err = client.Ping(ctx)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -145,6 +270,7 @@ func (p *PorkbunProvider) Configure(
 	}
 	resp.DataSourceData = &data
 	resp.ResourceData = &data
+	resp.EphemeralResourceData = &data
 }
 
 func (p *PorkbunProvider) DataSources(
@@ -152,11 +278,22 @@ func (p *PorkbunProvider) DataSources(
 ) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewPricingDataSource,
+		NewDomainAvailabilityDataSource,
 		NewDomainListDataSource,
 		NewDomainNameServersDataSource,
 		NewDomainURLForwardingDataSource,
 		NewDNSRecrodDataSource,
 		NewSSLBundleDataSource,
+		NewDomainDNSSECRecordsDataSource,
+		NewDomainGlueRecordsDataSource,
+	}
+}
+
+func (p *PorkbunProvider) EphemeralResources(
+	_ context.Context,
+) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewSSLBundleEphemeralResource,
 	}
 }
 
@@ -167,6 +304,15 @@ func (p *PorkbunProvider) Resources(
 		NewDomainNameServersResource,
 		NewDomainURLForwardResource,
 		NewDNSRecordResource,
+		NewDNSRecordSetResource,
+		NewDNSZoneResource,
+		NewDomainDNSSECRecordResource,
+		NewDomainGlueRecordResource,
+		NewDomainResource,
+		NewDomainAutoRenewResource,
+		NewDomainAutoRenewBulkResource,
+		NewSSLCertificateFilesResource,
+		NewSSLCertificateReadyResource,
 	}
 }
 
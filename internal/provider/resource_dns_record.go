@@ -5,11 +5,15 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
@@ -23,6 +27,8 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &DNSRecordResource{}
+var _ resource.ResourceWithImportState = &DNSRecordResource{}
+var _ resource.ResourceWithValidateConfig = &DNSRecordResource{}
 
 type DNSRecordResource struct {
 	client *porkbun.Client
@@ -76,7 +82,8 @@ func (r *DNSRecordResource) Schema(
 			"subdomain": schema.StringAttribute{
 				Optional: true,
 				MarkdownDescription: "The subdomain for the record being created, not including the domain itself. " +
-					"Leave blank to create a record on the root domain. Use * to create a wildcard record.",
+					"Leave blank to create a record on the root domain. Use * to create a wildcard record. " +
+					"Fully qualified names of the domain, with or without the trailing dot, are accepted as well.",
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 253),
 				},
@@ -98,10 +105,19 @@ func (r *DNSRecordResource) Schema(
 						"CAA",
 					),
 				},
+				PlanModifiers: []planmodifier.String{
+					equalFold(),
+				},
 			},
 			"content": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The answer content for the record.",
+				Required: true,
+				MarkdownDescription: "The answer content for the record. " +
+					"SRV content is in the form 'weight port target', the priority is set separately. " +
+					"Differences in the way content is written, e.g. letter case of host names, " +
+					"trailing dots, IPv6 compression or TXT quoting, are not reported as changes.",
+				PlanModifiers: []planmodifier.String{
+					dnsContentEquality(path.Root("type")),
+				},
 			},
 			"ttl": schema.Int64Attribute{
 				Optional: true,
@@ -115,13 +131,13 @@ func (r *DNSRecordResource) Schema(
 			},
 			"priority": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "The priority of the record for those that support it.",
+				MarkdownDescription: "The priority of the record. Required for MX and SRV records and not allowed for other types.",
 				Validators: []validator.Int64{
 					int64validator.Between(0, int64(math.Pow(2, 16)-1)),
 				},
 			},
 			"notes": schema.StringAttribute{
-				Optional: true,
+				Optional:            true,
 				MarkdownDescription: "Currently doesn't do anything. :(",
 			},
 		},
@@ -150,6 +166,31 @@ func (r *DNSRecordResource) Configure(
 	r.client = data.Client
 }
 
+func (r *DNSRecordResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var model DNSRecordResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Type.IsNull() || model.Type.IsUnknown() {
+		return
+	}
+
+	validateDNSRecordConfig(
+		&resp.Diagnostics,
+		model.Type.ValueString(),
+		path.Root("content"),
+		model.Content,
+		path.Root("priority"),
+		model.Priority,
+	)
+}
+
 func (r *DNSRecordResource) Create(
 	ctx context.Context,
 	req resource.CreateRequest,
@@ -181,7 +222,7 @@ func (r *DNSRecordResource) Create(
 		Notes:     notes,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -206,7 +247,18 @@ func (r *DNSRecordResource) Read(
 	records, errs := r.client.DNSRecords(ctx, domain, &id)
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			if errors.Is(err, porkbun.ErrNotFound) {
+				resp.Diagnostics.AddWarning("DNS Record Not Found", fmt.Sprintf(
+					"DNS record with the id '%d' no longer exists on '%s', "+
+						"most likely it was deleted outside of Terraform. "+
+						"It has been removed from the state and will be planned for creation.",
+					id,
+					domain,
+				))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
@@ -215,21 +267,41 @@ func (r *DNSRecordResource) Read(
 			"Expected to receive 1 record, got: %d.",
 			len(records),
 		))
+		return
 	}
 
 	record := records[0]
 
+	// Porkbun reports a priority of 0 for types that don't use it.
 	priority := types.Int64Null()
-	if record.Priority != nil {
+	if record.Priority != nil && dnsTypeUsesPriority(record.Type) {
 		priority = types.Int64Value(*record.Priority)
 	}
 
-	model.Subdomain = types.StringValue(record.Subdomain)
-	model.Type = types.StringValue(record.Type)
-	model.Content = types.StringValue(record.Content)
+	// Keep the configured form of the subdomain, e.g. a fully qualified name,
+	// as long as it refers to the same host.
+	if !porkbun.SameName(model.Subdomain.ValueString(), record.Subdomain, domain) {
+		model.Subdomain = types.StringNull()
+		if record.Subdomain != "" {
+			model.Subdomain = types.StringValue(record.Subdomain)
+		}
+	}
+	// Porkbun stores types in upper case and content in its own canonical
+	// form, so keep the configured values unless they actually changed.
+	if !strings.EqualFold(model.Type.ValueString(), record.Type) {
+		model.Type = types.StringValue(record.Type)
+	}
+	if model.Content.IsNull() ||
+		canonicalDNSContent(record.Type, model.Content.ValueString()) !=
+			canonicalDNSContent(record.Type, record.Content) {
+		model.Content = types.StringValue(record.Content)
+	}
 	model.TTL = types.Int64Value(record.TTL)
 	model.Priority = priority
-	model.Notes = types.StringValue(record.Notes)
+	// Keep notes null unless they were configured or set outside Terraform.
+	if record.Notes != "" || !model.Notes.IsNull() {
+		model.Notes = types.StringValue(record.Notes)
+	}
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -272,7 +344,7 @@ func (r *DNSRecordResource) Update(
 		Notes:     notes,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -295,8 +367,89 @@ func (r *DNSRecordResource) Delete(
 	id := model.ID.ValueInt64()
 
 	err := r.client.DeleteDNSRecord(ctx, domain, id)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+// ImportState accepts either 'domain/id' or the natural key
+// 'domain/type/subdomain[/content]', where subdomain is empty for the root
+// domain. The natural key has to match exactly one record.
+func (r *DNSRecordResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, "/", 4)
+	if len(parts) < 2 || parts[0] == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain/id' or 'domain/type/subdomain[/content]', got: '%s'.",
+			req.ID,
+		))
 		return
 	}
+	domain := parts[0]
+
+	var id int64
+	if len(parts) == 2 {
+		var err error
+		id, err = strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+				"Failed to parse record id as an integer with the following error: '%s'.",
+				err.Error(),
+			))
+			return
+		}
+	} else {
+		type_ := strings.ToUpper(parts[1])
+		subdomain := parts[2]
+
+		records, errs := r.client.DNSRecordsByTypeName(ctx, domain, type_, subdomain)
+		if errs != nil && len(errs) != 0 {
+			for _, err := range errs {
+				addClientError(&resp.Diagnostics, err)
+			}
+			return
+		}
+
+		candidates := []*porkbun.DNSRecord{}
+		for _, record := range records {
+			if len(parts) == 4 &&
+				canonicalDNSContent(type_, record.Content) != canonicalDNSContent(type_, parts[3]) {
+				continue
+			}
+			candidates = append(candidates, record)
+		}
+
+		if len(candidates) != 1 {
+			var list strings.Builder
+			for _, record := range candidates {
+				fmt.Fprintf(&list, "\n  - %s/%d (content: '%s')", domain, *record.ID, record.Content)
+			}
+			if len(candidates) == 0 {
+				resp.Diagnostics.AddError("No Matching DNS Record", fmt.Sprintf(
+					"Found no %s record for subdomain '%s' of '%s' matching the import ID '%s'.",
+					type_,
+					subdomain,
+					domain,
+					req.ID,
+				))
+			} else {
+				resp.Diagnostics.AddError("Ambiguous DNS Record", fmt.Sprintf(
+					"Found %d records matching the import ID '%s'. "+
+						"Add the content to the import ID or import by one of the following IDs:%s",
+					len(candidates),
+					req.ID,
+					list.String(),
+				))
+			}
+			return
+		}
+		id = *candidates[0].ID
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
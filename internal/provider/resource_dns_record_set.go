@@ -0,0 +1,509 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSRecordSetResource{}
+var _ resource.ResourceWithImportState = &DNSRecordSetResource{}
+var _ resource.ResourceWithValidateConfig = &DNSRecordSetResource{}
+
+type DNSRecordSetResource struct {
+	client *porkbun.Client
+}
+
+type DNSRecordSetResourceModel struct {
+	ID        types.String          `tfsdk:"id"`
+	Domain    types.String          `tfsdk:"domain"`
+	Subdomain types.String          `tfsdk:"subdomain"`
+	Type      types.String          `tfsdk:"type"`
+	TTL       types.Int64           `tfsdk:"ttl"`
+	Values    []recordSetValueModel `tfsdk:"values"`
+}
+
+type recordSetValueModel struct {
+	Content  types.String `tfsdk:"content"`
+	Priority types.Int64  `tfsdk:"priority"`
+}
+
+func NewDNSRecordSetResource() resource.Resource {
+	return &DNSRecordSetResource{}
+}
+
+func (r *DNSRecordSetResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dns_record_set"
+}
+
+func (r *DNSRecordSetResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manage all DNS records of a type on a subdomain as a single set. " +
+			"Records of the type on the subdomain that are not in the set are deleted.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the record set in the form 'domain/type/subdomain'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"subdomain": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The subdomain of the records, not including the domain itself. " +
+					"Leave blank to manage records on the root domain. Use * for wildcard records.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The type of the records. Valid types are: A, MX, CNAME, ALIAS, TXT, NS, AAAA, SRV, TLSA, CAA.",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(
+						"A",
+						"MX",
+						"CNAME",
+						"ALIAS",
+						"TXT",
+						"NS",
+						"AAAA",
+						"SRV",
+						"TLSA",
+						"CAA",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					equalFold(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ttl": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(600),
+				MarkdownDescription: "The time to live in seconds shared by all records of the set. " +
+					"The minimum and the default is 600 seconds.",
+				Validators: []validator.Int64{
+					int64validator.Between(600, int64(math.Pow(2, 31)-1)),
+				},
+			},
+			"values": schema.SetNestedAttribute{
+				Required: true,
+				MarkdownDescription: "The records of the set. " +
+					"Differences in the way content is written, e.g. letter case of host names, " +
+					"trailing dots, IPv6 compression or TXT quoting, are not reported as changes.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					recordSetValuesEquality(path.Root("type")),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The answer content for the record.",
+						},
+						"priority": schema.Int64Attribute{
+							Optional: true,
+							MarkdownDescription: "The priority of the record. " +
+								"Required for MX and SRV records and not allowed for other types.",
+							Validators: []validator.Int64{
+								int64validator.Between(0, int64(math.Pow(2, 16)-1)),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DNSRecordSetResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DNSRecordSetResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	// Values may be partially unknown, so they can't be read into the model.
+	var type_ types.String
+	var values types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("type"), &type_)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("values"), &values)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if type_.IsNull() || type_.IsUnknown() || values.IsUnknown() {
+		return
+	}
+
+	for _, element := range values.Elements() {
+		value, ok := element.(types.Object)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		attrs := value.Attributes()
+		content, _ := attrs["content"].(types.String)
+		priority, _ := attrs["priority"].(types.Int64)
+		validateDNSRecordConfig(
+			&resp.Diagnostics,
+			type_.ValueString(),
+			path.Root("values").AtSetValue(value).AtName("content"),
+			content,
+			path.Root("values").AtSetValue(value).AtName("priority"),
+			priority,
+		)
+	}
+}
+
+func (r *DNSRecordSetResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DNSRecordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(recordSetID(&model))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSRecordSetResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DNSRecordSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	subdomain := model.Subdomain.ValueString()
+	type_ := strings.ToUpper(model.Type.ValueString())
+
+	records, errs := r.client.DNSRecordsByTypeName(ctx, domain, type_, subdomain)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+	if len(records) == 0 {
+		resp.Diagnostics.AddWarning("DNS Record Set Not Found", fmt.Sprintf(
+			"No %s records exist on '%s' anymore, most likely they were deleted outside of Terraform. "+
+				"The record set has been removed from the state and will be planned for creation.",
+			type_,
+			porkbun.FQDN(subdomain, domain),
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	values := []recordSetValueModel{}
+	ttl := model.TTL
+	for _, record := range records {
+		value := recordSetValueFrom(type_, record)
+		// Keep the configured form of the content if it's semantically equal.
+		for _, prior := range model.Values {
+			if sameRecordSetValue(type_, prior, value) {
+				value.Content = prior.Content
+				break
+			}
+		}
+		values = append(values, value)
+		if ttl.ValueInt64() != record.TTL {
+			ttl = types.Int64Value(record.TTL)
+		}
+	}
+
+	model.TTL = ttl
+	model.Values = values
+	model.ID = types.StringValue(recordSetID(&model))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSRecordSetResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var model DNSRecordSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.ID = types.StringValue(recordSetID(&model))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSRecordSetResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model DNSRecordSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	subdomain := model.Subdomain.ValueString()
+	type_ := strings.ToUpper(model.Type.ValueString())
+
+	err := r.client.DeleteDNSRecordsByNameType(ctx, domain, type_, subdomain)
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+// ImportState accepts 'domain/type/subdomain', where subdomain is empty (or
+// omitted along with the slash) for the root domain.
+func (r *DNSRecordSetResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain/type/subdomain', got: '%s'.",
+			req.ID,
+		))
+		return
+	}
+
+	subdomain := types.StringNull()
+	if len(parts) == 3 && parts[2] != "" {
+		subdomain = types.StringValue(parts[2])
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("type"), strings.ToUpper(parts[1]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("subdomain"), subdomain)...)
+}
+
+// reconcile makes the records of the type on the subdomain match the model
+// with as few API calls as possible. Records that already match are left
+// alone, stale records are edited into missing ones and any remaining stale
+// records are deleted or missing ones created.
+func (r *DNSRecordSetResource) reconcile(
+	ctx context.Context,
+	model *DNSRecordSetResourceModel,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	domain := model.Domain.ValueString()
+	subdomain := model.Subdomain.ValueString()
+	type_ := strings.ToUpper(model.Type.ValueString())
+	ttl := model.TTL.ValueInt64()
+
+	existing, errs := r.client.DNSRecordsByTypeName(ctx, domain, type_, subdomain)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&diags, err)
+		}
+		return diags
+	}
+
+	toRecord := func(value recordSetValueModel) *porkbun.DNSRecord {
+		var priority *int64
+		if !value.Priority.IsNull() {
+			priority = &[]int64{value.Priority.ValueInt64()}[0]
+		}
+		return &porkbun.DNSRecord{
+			Subdomain: subdomain,
+			Type:      type_,
+			Content:   value.Content.ValueString(),
+			TTL:       ttl,
+			Priority:  priority,
+		}
+	}
+
+	// A single record can be changed in place without looking up its ID.
+	if len(model.Values) == 1 && len(existing) == 1 {
+		record := toRecord(model.Values[0])
+		current := recordSetValueFrom(type_, existing[0])
+		if sameRecordSetValue(type_, model.Values[0], current) && existing[0].TTL == ttl {
+			return diags
+		}
+		err := r.client.EditDNSRecordsByNameType(ctx, domain, type_, subdomain, record)
+		if err != nil {
+			addClientError(&diags, err)
+		}
+		return diags
+	}
+
+	matched := make([]bool, len(existing))
+	missing := []recordSetValueModel{}
+	for _, value := range model.Values {
+		found := false
+		for i, record := range existing {
+			if matched[i] || !sameRecordSetValue(type_, value, recordSetValueFrom(type_, record)) {
+				continue
+			}
+			matched[i] = true
+			found = true
+			if record.TTL != ttl {
+				edit := toRecord(value)
+				edit.ID = record.ID
+				err := r.client.EditDNSRecord(ctx, domain, edit)
+				if err != nil {
+					addClientError(&diags, err)
+					return diags
+				}
+			}
+			break
+		}
+		if !found {
+			missing = append(missing, value)
+		}
+	}
+
+	stale := []*porkbun.DNSRecord{}
+	for i, record := range existing {
+		if !matched[i] {
+			stale = append(stale, record)
+		}
+	}
+
+	for len(missing) > 0 && len(stale) > 0 {
+		edit := toRecord(missing[0])
+		edit.ID = stale[0].ID
+		err := r.client.EditDNSRecord(ctx, domain, edit)
+		if err != nil {
+			addClientError(&diags, err)
+			return diags
+		}
+		missing, stale = missing[1:], stale[1:]
+	}
+	for _, record := range stale {
+		err := r.client.DeleteDNSRecord(ctx, domain, *record.ID)
+		if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+			addClientError(&diags, err)
+			return diags
+		}
+	}
+	for _, value := range missing {
+		_, err := r.client.CreateDNSRecord(ctx, domain, toRecord(value))
+		if err != nil {
+			addClientError(&diags, err)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+func recordSetValueFrom(
+	type_ string,
+	record *porkbun.DNSRecord,
+) recordSetValueModel {
+	value := recordSetValueModel{
+		Content:  types.StringValue(record.Content),
+		Priority: types.Int64Null(),
+	}
+	// Porkbun reports a priority of 0 for types that don't use it.
+	if record.Priority != nil && dnsTypeUsesPriority(type_) {
+		value.Priority = types.Int64Value(*record.Priority)
+	}
+	return value
+}
+
+func sameRecordSetValue(
+	type_ string,
+	a recordSetValueModel,
+	b recordSetValueModel,
+) bool {
+	return a.Priority.Equal(b.Priority) &&
+		canonicalDNSContent(type_, a.Content.ValueString()) ==
+			canonicalDNSContent(type_, b.Content.ValueString())
+}
+
+func recordSetID(model *DNSRecordSetResourceModel) string {
+	return model.Domain.ValueString() + "/" +
+		strings.ToUpper(model.Type.ValueString()) + "/" +
+		porkbun.RelativeName(model.Subdomain.ValueString(), model.Domain.ValueString())
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDNSRecordSetReconcile(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []map[string]any
+		type_    string
+		ttl      int64
+		values   []recordSetValueModel
+		want     []string
+	}{
+		{
+			name:     "single unchanged",
+			existing: []map[string]any{fakeRecord("www.example.com", "TXT", "v=spf1 -all", 600)},
+			type_:    "TXT",
+			values:   []recordSetValueModel{recordSetValue(`"v=spf1 -all"`)},
+			want:     []string{},
+		},
+		{
+			name:     "single changed",
+			existing: []map[string]any{fakeRecord("www.example.com", "A", "192.0.2.1", 600)},
+			type_:    "A",
+			values:   []recordSetValueModel{recordSetValue("192.0.2.2")},
+			want:     []string{"editByNameType www A 192.0.2.2 600"},
+		},
+		{
+			name:     "single ttl changed",
+			existing: []map[string]any{fakeRecord("www.example.com", "A", "192.0.2.1", 600)},
+			type_:    "a",
+			ttl:      3600,
+			values:   []recordSetValueModel{recordSetValue("192.0.2.1")},
+			want:     []string{"editByNameType www A 192.0.2.1 3600"},
+		},
+		{
+			name:     "created",
+			existing: []map[string]any{},
+			type_:    "A",
+			values:   []recordSetValueModel{recordSetValue("192.0.2.1"), recordSetValue("192.0.2.2")},
+			want:     []string{"create www A 192.0.2.1 600", "create www A 192.0.2.2 600"},
+		},
+		{
+			name: "several unchanged",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "CNAME", "a.example.net", 600),
+				fakeRecord("www.example.com", "CNAME", "b.example.net", 600),
+			},
+			type_:  "CNAME",
+			values: []recordSetValueModel{recordSetValue("B.example.net."), recordSetValue("a.example.net")},
+			want:   []string{},
+		},
+		{
+			name: "added",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+			},
+			type_:  "A",
+			values: []recordSetValueModel{recordSetValue("192.0.2.1"), recordSetValue("192.0.2.2")},
+			want:   []string{"create www A 192.0.2.2 600"},
+		},
+		{
+			name: "removed",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "A", "192.0.2.2", 600),
+			},
+			type_:  "A",
+			values: []recordSetValueModel{recordSetValue("192.0.2.2")},
+			want:   []string{"delete 1"},
+		},
+		{
+			name: "replaced",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "A", "192.0.2.2", 600),
+			},
+			type_:  "A",
+			values: []recordSetValueModel{recordSetValue("192.0.2.1"), recordSetValue("192.0.2.3")},
+			want:   []string{"edit 2 www A 192.0.2.3 600"},
+		},
+		{
+			name: "replaced and removed",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "A", "192.0.2.2", 600),
+				fakeRecord("www.example.com", "A", "192.0.2.3", 600),
+			},
+			type_:  "A",
+			values: []recordSetValueModel{recordSetValue("192.0.2.1"), recordSetValue("192.0.2.4")},
+			want:   []string{"delete 3", "edit 2 www A 192.0.2.4 600"},
+		},
+		{
+			name: "ttl changed",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "A", "192.0.2.2", 600),
+			},
+			type_:  "A",
+			ttl:    3600,
+			values: []recordSetValueModel{recordSetValue("192.0.2.1"), recordSetValue("192.0.2.2")},
+			want:   []string{"edit 1 www A 192.0.2.1 3600", "edit 2 www A 192.0.2.2 3600"},
+		},
+		{
+			name: "other records untouched",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "AAAA", "2001:db8::1", 600),
+				fakeRecord("example.com", "A", "192.0.2.1", 600),
+				fakeRecord("mail.www.example.com", "A", "192.0.2.1", 600),
+			},
+			type_:  "A",
+			values: []recordSetValueModel{recordSetValue("192.0.2.2"), recordSetValue("192.0.2.3")},
+			want:   []string{"create www A 192.0.2.3 600", "edit 1 www A 192.0.2.2 600"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFakeDNSServer(t, "example.com", test.existing, nil)
+			r := &DNSRecordSetResource{client: server.client}
+
+			ttl := test.ttl
+			if ttl == 0 {
+				ttl = 600
+			}
+			diags := r.reconcile(context.Background(), &DNSRecordSetResourceModel{
+				Domain:    types.StringValue("example.com"),
+				Subdomain: types.StringValue("www"),
+				Type:      types.StringValue(test.type_),
+				TTL:       types.Int64Value(ttl),
+				Values:    test.values,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			got := append([]string{}, server.changes...)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("changes = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func recordSetValue(content string) recordSetValueModel {
+	return recordSetValueModel{
+		Content:  types.StringValue(content),
+		Priority: types.Int64Null(),
+	}
+}
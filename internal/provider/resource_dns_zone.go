@@ -0,0 +1,556 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	pathpkg "path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DNSZoneResource{}
+var _ resource.ResourceWithImportState = &DNSZoneResource{}
+var _ resource.ResourceWithValidateConfig = &DNSZoneResource{}
+
+const defaultTTL = 600
+
+type DNSZoneResource struct {
+	client *porkbun.Client
+}
+
+type DNSZoneResourceModel struct {
+	Domain  types.String      `tfsdk:"domain"`
+	Records []zoneRecordModel `tfsdk:"records"`
+	Ignore  []zoneIgnoreModel `tfsdk:"ignore"`
+}
+
+type zoneRecordModel struct {
+	Subdomain types.String `tfsdk:"subdomain"`
+	Type      types.String `tfsdk:"type"`
+	Content   types.String `tfsdk:"content"`
+	TTL       types.Int64  `tfsdk:"ttl"`
+	Priority  types.Int64  `tfsdk:"priority"`
+}
+
+type zoneIgnoreModel struct {
+	Type      types.String `tfsdk:"type"`
+	Subdomain types.String `tfsdk:"subdomain"`
+}
+
+func NewDNSZoneResource() resource.Resource {
+	return &DNSZoneResource{}
+}
+
+func (r *DNSZoneResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_dns_zone"
+}
+
+func (r *DNSZoneResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Authoritatively manage every editable DNS record of a domain. " +
+			"Records that are not in the configuration are deleted, unless they match one of the ignore filters. " +
+			"Note that this includes the NS records of the root domain, " +
+			"add an ignore filter for them if you don't manage them here.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"records": schema.SetNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Every DNS record of the domain, except for the ignored ones.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"subdomain": schema.StringAttribute{
+							Optional: true,
+							MarkdownDescription: "The subdomain of the record, not including the domain itself. " +
+								"Leave blank for a record on the root domain. Use * for a wildcard record.",
+							Validators: []validator.String{
+								stringvalidator.LengthBetween(1, 253),
+							},
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The type of the record. Valid types are: A, MX, CNAME, ALIAS, TXT, NS, AAAA, SRV, TLSA, CAA.",
+							Validators: []validator.String{
+								stringvalidator.OneOfCaseInsensitive(
+									"A",
+									"MX",
+									"CNAME",
+									"ALIAS",
+									"TXT",
+									"NS",
+									"AAAA",
+									"SRV",
+									"TLSA",
+									"CAA",
+								),
+							},
+						},
+						"content": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "The answer content for the record.",
+						},
+						"ttl": schema.Int64Attribute{
+							Optional: true,
+							MarkdownDescription: "The time to live in seconds for the record. " +
+								"The minimum and the default is 600 seconds.",
+							Validators: []validator.Int64{
+								int64validator.Between(600, int64(math.Pow(2, 31)-1)),
+							},
+						},
+						"priority": schema.Int64Attribute{
+							Optional: true,
+							MarkdownDescription: "The priority of the record. " +
+								"Required for MX and SRV records and not allowed for other types.",
+							Validators: []validator.Int64{
+								int64validator.Between(0, int64(math.Pow(2, 16)-1)),
+							},
+						},
+					},
+				},
+			},
+			"ignore": schema.ListNestedAttribute{
+				Optional: true,
+				MarkdownDescription: "Filters for records managed elsewhere. " +
+					"A record is ignored if it matches all attributes set in any of the filters.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "The type of the records to ignore.",
+						},
+						"subdomain": schema.StringAttribute{
+							Optional: true,
+							MarkdownDescription: "A shell pattern matching the subdomain of the records to ignore, " +
+								"e.g. '_acme-challenge*'. Use an empty string for the root domain.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DNSZoneResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DNSZoneResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	// Records may be partially unknown, so they can't be read into the model.
+	var domain types.String
+	var records types.Set
+	var ignoreList types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("domain"), &domain)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("records"), &records)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ignore"), &ignoreList)...)
+	if resp.Diagnostics.HasError() || records.IsUnknown() || ignoreList.IsUnknown() {
+		return
+	}
+
+	var ignore []zoneIgnoreModel
+	if !ignoreList.IsNull() {
+		diags := ignoreList.ElementsAs(ctx, &ignore, false)
+		if diags.HasError() {
+			// Filters with unknown values are validated once they're known.
+			return
+		}
+	}
+
+	for _, element := range records.Elements() {
+		value, ok := element.(types.Object)
+		if !ok || value.IsUnknown() {
+			continue
+		}
+		attrs := value.Attributes()
+		subdomain, _ := attrs["subdomain"].(types.String)
+		type_, _ := attrs["type"].(types.String)
+		content, _ := attrs["content"].(types.String)
+		priority, _ := attrs["priority"].(types.Int64)
+		if type_.IsUnknown() {
+			continue
+		}
+
+		validateDNSRecordConfig(
+			&resp.Diagnostics,
+			type_.ValueString(),
+			path.Root("records").AtSetValue(value).AtName("content"),
+			content,
+			path.Root("records").AtSetValue(value).AtName("priority"),
+			priority,
+		)
+
+		// The filters match relative names, like the records read from Porkbun.
+		if subdomain.IsUnknown() || domain.IsUnknown() {
+			continue
+		}
+		relative := porkbun.RelativeName(subdomain.ValueString(), domain.ValueString())
+		if zoneIgnored(ignore, relative, type_.ValueString()) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("records").AtSetValue(value),
+				"Ignored DNS Record",
+				fmt.Sprintf(
+					"The %s record for subdomain '%s' matches one of the ignore filters, "+
+						"so it would never be managed. Remove it or adjust the filters.",
+					strings.ToUpper(type_.ValueString()),
+					subdomain.ValueString(),
+				),
+			)
+		}
+	}
+}
+
+func (r *DNSZoneResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DNSZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSZoneResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DNSZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, diags := r.managedRecords(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	records := []zoneRecordModel{}
+	for _, record := range existing {
+		value := zoneRecordModel{
+			Subdomain: types.StringNull(),
+			Type:      types.StringValue(record.Type),
+			Content:   types.StringValue(record.Content),
+			TTL:       types.Int64Value(record.TTL),
+			Priority:  types.Int64Null(),
+		}
+		if record.Subdomain != "" {
+			value.Subdomain = types.StringValue(record.Subdomain)
+		}
+		// Porkbun reports a priority of 0 for types that don't use it.
+		if record.Priority != nil && dnsTypeUsesPriority(record.Type) {
+			value.Priority = types.Int64Value(*record.Priority)
+		}
+		if record.TTL == defaultTTL {
+			value.TTL = types.Int64Null()
+		}
+
+		// Keep the configured form of the record if it's semantically equal.
+		for _, prior := range model.Records {
+			if sameZoneRecord(domain, prior, record) {
+				value.Subdomain = prior.Subdomain
+				value.Type = prior.Type
+				value.Content = prior.Content
+				if record.TTL == defaultTTL {
+					value.TTL = prior.TTL
+				}
+				break
+			}
+		}
+		records = append(records, value)
+	}
+
+	model.Records = records
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSZoneResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var model DNSZoneResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.reconcile(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DNSZoneResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model DNSZoneResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	existing, diags := r.managedRecords(ctx, &model)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	for _, record := range existing {
+		err := r.client.DeleteDNSRecord(ctx, domain, *record.ID)
+		if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+			addClientError(&resp.Diagnostics, err)
+			return
+		}
+	}
+}
+
+func (r *DNSZoneResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain"), req, resp)
+}
+
+// managedRecords returns the records of the domain that aren't ignored.
+func (r *DNSZoneResource) managedRecords(
+	ctx context.Context,
+	model *DNSZoneResourceModel,
+) (
+	[]*porkbun.DNSRecord,
+	diag.Diagnostics,
+) {
+	diags := diag.Diagnostics{}
+
+	records, errs := r.client.DNSRecords(ctx, model.Domain.ValueString(), nil)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&diags, err)
+		}
+		return nil, diags
+	}
+
+	managed := []*porkbun.DNSRecord{}
+	for _, record := range records {
+		if !zoneIgnored(model.Ignore, record.Subdomain, record.Type) {
+			managed = append(managed, record)
+		}
+	}
+	return managed, diags
+}
+
+// reconcile makes the records of the domain match the model with the minimal
+// number of API calls. Records that match the configuration exactly are kept
+// (and edited if only their TTL or priority changed), a changed content on
+// the same subdomain and type is edited in place and the rest is created or
+// deleted.
+func (r *DNSZoneResource) reconcile(
+	ctx context.Context,
+	model *DNSZoneResourceModel,
+) diag.Diagnostics {
+	domain := model.Domain.ValueString()
+
+	existing, diags := r.managedRecords(ctx, model)
+	if diags.HasError() {
+		return diags
+	}
+
+	toRecord := func(value zoneRecordModel) *porkbun.DNSRecord {
+		ttl := int64(defaultTTL)
+		if !value.TTL.IsNull() {
+			ttl = value.TTL.ValueInt64()
+		}
+		var priority *int64
+		if !value.Priority.IsNull() {
+			priority = &[]int64{value.Priority.ValueInt64()}[0]
+		}
+		return &porkbun.DNSRecord{
+			Subdomain: value.Subdomain.ValueString(),
+			Type:      strings.ToUpper(value.Type.ValueString()),
+			Content:   value.Content.ValueString(),
+			TTL:       ttl,
+			Priority:  priority,
+		}
+	}
+
+	matched := make([]bool, len(existing))
+	missing := []zoneRecordModel{}
+	for _, value := range model.Records {
+		found := false
+		for i, record := range existing {
+			if matched[i] || !sameZoneRecord(domain, value, record) {
+				continue
+			}
+			matched[i] = true
+			found = true
+			want := toRecord(value)
+			if want.TTL != record.TTL || !samePriority(want, record) {
+				want.ID = record.ID
+				err := r.client.EditDNSRecord(ctx, domain, want)
+				if err != nil {
+					addClientError(&diags, err)
+					return diags
+				}
+			}
+			break
+		}
+		if !found {
+			missing = append(missing, value)
+		}
+	}
+
+	for _, value := range missing {
+		want := toRecord(value)
+		for i, record := range existing {
+			if matched[i] ||
+				!strings.EqualFold(want.Type, record.Type) ||
+				!porkbun.SameName(want.Subdomain, record.Subdomain, domain) {
+				continue
+			}
+			matched[i] = true
+			want.ID = record.ID
+			break
+		}
+
+		var err error
+		if want.ID != nil {
+			err = r.client.EditDNSRecord(ctx, domain, want)
+		} else {
+			_, err = r.client.CreateDNSRecord(ctx, domain, want)
+		}
+		if err != nil {
+			addClientError(&diags, err)
+			return diags
+		}
+	}
+
+	for i, record := range existing {
+		if matched[i] {
+			continue
+		}
+		err := r.client.DeleteDNSRecord(ctx, domain, *record.ID)
+		if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+			addClientError(&diags, err)
+			return diags
+		}
+	}
+
+	return diags
+}
+
+// zoneIgnored reports whether a record matches any of the ignore filters.
+func zoneIgnored(
+	ignore []zoneIgnoreModel,
+	subdomain string,
+	type_ string,
+) bool {
+	for _, filter := range ignore {
+		if !filter.Type.IsNull() && !strings.EqualFold(filter.Type.ValueString(), type_) {
+			continue
+		}
+		if !filter.Subdomain.IsNull() {
+			ok, err := pathpkg.Match(
+				strings.ToLower(filter.Subdomain.ValueString()),
+				strings.ToLower(subdomain),
+			)
+			if err != nil || !ok {
+				continue
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func sameZoneRecord(
+	domain string,
+	value zoneRecordModel,
+	record *porkbun.DNSRecord,
+) bool {
+	return strings.EqualFold(value.Type.ValueString(), record.Type) &&
+		porkbun.SameName(value.Subdomain.ValueString(), record.Subdomain, domain) &&
+		canonicalDNSContent(record.Type, value.Content.ValueString()) ==
+			canonicalDNSContent(record.Type, record.Content)
+}
+
+func samePriority(a *porkbun.DNSRecord, b *porkbun.DNSRecord) bool {
+	if !dnsTypeUsesPriority(a.Type) {
+		return true
+	}
+	if a.Priority == nil || b.Priority == nil {
+		return a.Priority == b.Priority
+	}
+	return *a.Priority == *b.Priority
+}
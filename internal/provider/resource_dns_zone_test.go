@@ -0,0 +1,419 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+func TestZoneIgnored(t *testing.T) {
+	tests := []struct {
+		name      string
+		ignore    []zoneIgnoreModel
+		subdomain string
+		type_     string
+		ignored   bool
+	}{
+		{"no filters", nil, "www", "A", false},
+		{"type", []zoneIgnoreModel{ignoreFilter("NS", "")}, "", "NS", true},
+		{"type case", []zoneIgnoreModel{ignoreFilter("ns", "")}, "", "NS", true},
+		{"other type", []zoneIgnoreModel{ignoreFilter("NS", "")}, "", "A", false},
+		{"subdomain", []zoneIgnoreModel{ignoreFilter("", "www")}, "www", "AAAA", true},
+		{"subdomain case", []zoneIgnoreModel{ignoreFilter("", "WWW")}, "www", "A", true},
+		{"other subdomain", []zoneIgnoreModel{ignoreFilter("", "www")}, "mail", "A", false},
+		{"pattern", []zoneIgnoreModel{ignoreFilter("", "_acme-challenge*")}, "_acme-challenge.www", "TXT", true},
+		{"pattern prefix only", []zoneIgnoreModel{ignoreFilter("", "_acme-challenge*")}, "x._acme-challenge", "TXT", false},
+		{"apex", []zoneIgnoreModel{ignoreFilter("", "")}, "", "MX", true},
+		{"type and subdomain", []zoneIgnoreModel{ignoreFilter("TXT", "_dmarc")}, "_dmarc", "TXT", true},
+		{"type and other subdomain", []zoneIgnoreModel{ignoreFilter("TXT", "_dmarc")}, "", "TXT", false},
+		{"subdomain and other type", []zoneIgnoreModel{ignoreFilter("TXT", "_dmarc")}, "_dmarc", "CNAME", false},
+		{"any filter", []zoneIgnoreModel{ignoreFilter("MX", ""), ignoreFilter("", "www")}, "www", "A", true},
+		{"invalid pattern", []zoneIgnoreModel{ignoreFilter("", "[")}, "[", "A", false},
+	}
+
+	for _, test := range tests {
+		got := zoneIgnored(test.ignore, test.subdomain, test.type_)
+		if got != test.ignored {
+			t.Errorf("%s: zoneIgnored(%q, %q) = %t, want %t", test.name, test.subdomain, test.type_, got, test.ignored)
+		}
+	}
+}
+
+func TestDNSZoneValidateConfigIgnored(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&DNSZoneResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	tests := []struct {
+		subdomain string
+		ignored   bool
+	}{
+		{"www", false},
+		{"_acme-challenge", true},
+		{"_acme-challenge.example.com", true},
+		{"_ACME-challenge.Example.com.", true},
+		{"_acme-challenge.example.net", false},
+		{"@", false},
+		{"example.com", false},
+	}
+
+	for _, test := range tests {
+		// The configuration is built like a state, as it can't be set.
+		configured := tfsdk.State{Schema: s, Raw: null}
+		diags := configured.Set(ctx, &DNSZoneResourceModel{
+			Domain:  types.StringValue("example.com"),
+			Records: []zoneRecordModel{zoneRecord(test.subdomain, "TXT", "token")},
+			Ignore:  []zoneIgnoreModel{ignoreFilter("TXT", "_acme-challenge")},
+		})
+		if diags.HasError() {
+			t.Fatal(diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		(&DNSZoneResource{}).ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: s, Raw: configured.Raw},
+		}, resp)
+		if resp.Diagnostics.HasError() != test.ignored {
+			t.Errorf("ValidateConfig() for subdomain %q = %v, want an error: %t", test.subdomain, resp.Diagnostics, test.ignored)
+		}
+	}
+}
+
+func TestDNSZoneReconcile(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing []map[string]any
+		gone     []string
+		records  []zoneRecordModel
+		ignore   []zoneIgnoreModel
+		want     []string
+	}{
+		{
+			name:     "unchanged",
+			existing: []map[string]any{fakeRecord("www.example.com", "A", "192.0.2.1", 600)},
+			records:  []zoneRecordModel{zoneRecord("www", "A", "192.0.2.1")},
+			want:     []string{},
+		},
+		{
+			name: "written differently",
+			existing: []map[string]any{
+				fakeRecord("example.com", "AAAA", "2001:db8::1", 600),
+				fakeRecord("www.example.com", "CNAME", "example.com", 600),
+			},
+			records: []zoneRecordModel{
+				zoneRecord("@", "aaaa", "2001:DB8::1"),
+				zoneRecord("WWW.example.com.", "CNAME", "Example.com."),
+			},
+			want: []string{},
+		},
+		{
+			name:     "content changed",
+			existing: []map[string]any{fakeRecord("www.example.com", "A", "192.0.2.1", 600)},
+			records:  []zoneRecordModel{zoneRecord("www", "A", "192.0.2.2")},
+			want:     []string{"edit 1 www A 192.0.2.2 600"},
+		},
+		{
+			name:     "ttl changed",
+			existing: []map[string]any{fakeRecord("www.example.com", "A", "192.0.2.1", 3600)},
+			records:  []zoneRecordModel{zoneRecord("www", "A", "192.0.2.1")},
+			want:     []string{"edit 1 www A 192.0.2.1 600"},
+		},
+		{
+			name:     "created",
+			existing: []map[string]any{},
+			records:  []zoneRecordModel{zoneRecord("", "TXT", "hello")},
+			want:     []string{"create  TXT hello 600"},
+		},
+		{
+			name: "deleted",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+				fakeRecord("old.example.com", "A", "192.0.2.9", 600),
+			},
+			records: []zoneRecordModel{zoneRecord("www", "A", "192.0.2.1")},
+			want:    []string{"delete 2"},
+		},
+		{
+			name: "all deleted",
+			existing: []map[string]any{
+				fakeRecord("example.com", "A", "192.0.2.1", 600),
+				fakeRecord("www.example.com", "CNAME", "example.com", 600),
+			},
+			records: []zoneRecordModel{},
+			want:    []string{"delete 1", "delete 2"},
+		},
+		{
+			name: "deleted outside of terraform",
+			existing: []map[string]any{
+				fakeRecord("old.example.com", "A", "192.0.2.9", 600),
+				fakeRecord("older.example.com", "A", "192.0.2.8", 600),
+			},
+			gone:    []string{"1"},
+			records: []zoneRecordModel{},
+			want:    []string{"delete 2"},
+		},
+		{
+			name: "one of several changed",
+			existing: []map[string]any{
+				fakeRecord("example.com", "TXT", "a", 600),
+				fakeRecord("example.com", "TXT", "b", 600),
+			},
+			records: []zoneRecordModel{zoneRecord("", "TXT", "a"), zoneRecord("", "TXT", "c")},
+			want:    []string{"edit 2  TXT c 600"},
+		},
+		{
+			name: "type changed",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+			},
+			records: []zoneRecordModel{zoneRecord("www", "CNAME", "example.com")},
+			want:    []string{"create www CNAME example.com 600", "delete 1"},
+		},
+		{
+			name: "ignored by type",
+			existing: []map[string]any{
+				fakeRecord("example.com", "NS", "curitiba.ns.porkbun.com", 86400),
+				fakeRecord("old.example.com", "A", "192.0.2.9", 600),
+			},
+			records: []zoneRecordModel{},
+			ignore:  []zoneIgnoreModel{ignoreFilter("ns", "")},
+			want:    []string{"delete 2"},
+		},
+		{
+			name: "ignored by subdomain",
+			existing: []map[string]any{
+				fakeRecord("_acme-challenge.example.com", "TXT", "token", 600),
+				fakeRecord("_acme-challenge.www.example.com", "TXT", "token", 600),
+				fakeRecord("_dmarc.example.com", "TXT", "v=DMARC1", 600),
+			},
+			records: []zoneRecordModel{},
+			ignore:  []zoneIgnoreModel{ignoreFilter("TXT", "_ACME-CHALLENGE*")},
+			want:    []string{"delete 3"},
+		},
+		{
+			name: "ignored not reused",
+			existing: []map[string]any{
+				fakeRecord("www.example.com", "A", "192.0.2.1", 600),
+			},
+			records: []zoneRecordModel{zoneRecord("www", "A", "192.0.2.2")},
+			ignore:  []zoneIgnoreModel{ignoreFilter("A", "www")},
+			want:    []string{"create www A 192.0.2.2 600"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := newFakeDNSServer(t, "example.com", test.existing, test.gone)
+			r := &DNSZoneResource{client: server.client}
+
+			diags := r.reconcile(context.Background(), &DNSZoneResourceModel{
+				Domain:  types.StringValue("example.com"),
+				Records: test.records,
+				Ignore:  test.ignore,
+			})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			got := append([]string{}, server.changes...)
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("changes = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+// fakeDNSServer serves the DNS record endpoints of the Porkbun API for a
+// single domain and records the changes made to its records.
+type fakeDNSServer struct {
+	client *porkbun.Client
+
+	mu      sync.Mutex
+	domain  string
+	records map[string]map[string]any
+	gone    map[string]bool
+	nextID  int
+	changes []string
+}
+
+// newFakeDNSServer starts a server with the records, given IDs counting from
+// 1. The records with the IDs in gone are listed, but fail to be deleted as
+// if they were already deleted by someone else.
+func newFakeDNSServer(
+	t *testing.T,
+	domain string,
+	records []map[string]any,
+	gone []string,
+) *fakeDNSServer {
+	f := &fakeDNSServer{
+		domain:  domain,
+		records: map[string]map[string]any{},
+		gone:    map[string]bool{},
+		nextID:  len(records),
+	}
+	for i, record := range records {
+		id := fmt.Sprint(i + 1)
+		record["id"] = id
+		f.records[id] = record
+	}
+	for _, id := range gone {
+		f.gone[id] = true
+	}
+
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.client = porkbun.NewClient(server.Client(), baseURL, "pk1_test", "sk1_test", nil)
+	return f
+}
+
+func (f *fakeDNSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	delete(body, "apikey")
+	delete(body, "secretapikey")
+
+	// The paths are dns/<action>/<domain>[/<id>] or
+	// dns/<action>ByNameType/<domain>/<type>[/<subdomain>].
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "dns" || parts[2] != f.domain {
+		http.NotFound(w, r)
+		return
+	}
+	byNameType := func(record map[string]any) bool {
+		name := ""
+		if len(parts) == 5 {
+			name = parts[4]
+		}
+		return len(parts) >= 4 && record["type"] == parts[3] &&
+			porkbun.SameName(fmt.Sprint(record["name"]), name, f.domain)
+	}
+
+	res := map[string]any{"status": "SUCCESS"}
+	switch {
+	case parts[1] == "retrieve" && len(parts) == 3:
+		res["records"] = f.sortedRecords()
+	case parts[1] == "retrieveByNameType":
+		records := []map[string]any{}
+		for _, record := range f.sortedRecords() {
+			if byNameType(record) {
+				records = append(records, record)
+			}
+		}
+		res["records"] = records
+	case parts[1] == "editByNameType":
+		f.changes = append(f.changes, fmt.Sprintf("editByNameType %v %v %v %v", body["name"], parts[3], body["content"], body["ttl"]))
+		for id, record := range f.records {
+			if byNameType(record) {
+				record["content"] = body["content"]
+				record["ttl"] = body["ttl"]
+				record["prio"] = body["prio"]
+				f.records[id] = record
+			}
+		}
+	case parts[1] == "create" && len(parts) == 3:
+		f.nextID++
+		f.changes = append(f.changes, fmt.Sprintf("create %v %v %v %v", body["name"], body["type"], body["content"], body["ttl"]))
+		f.store(fmt.Sprint(f.nextID), body)
+		res["id"] = f.nextID
+	case parts[1] == "edit" && len(parts) == 4 && f.records[parts[3]] != nil:
+		f.changes = append(f.changes, fmt.Sprintf("edit %s %v %v %v %v", parts[3], body["name"], body["type"], body["content"], body["ttl"]))
+		f.store(parts[3], body)
+	case parts[1] == "delete" && len(parts) == 4 && f.records[parts[3]] != nil && !f.gone[parts[3]]:
+		f.changes = append(f.changes, "delete "+parts[3])
+		delete(f.records, parts[3])
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		res = map[string]any{"status": "ERROR", "message": "Invalid record id."}
+	}
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+// store saves a record sent to the API, which Porkbun returns with a fully
+// qualified name.
+func (f *fakeDNSServer) store(id string, record map[string]any) {
+	record["id"] = id
+	record["name"] = porkbun.FQDN(fmt.Sprint(record["name"]), f.domain)
+	f.records[id] = record
+}
+
+// sortedRecords returns the records ordered by ID, like Porkbun lists them.
+func (f *fakeDNSServer) sortedRecords() []map[string]any {
+	ids := []int{}
+	for id := range f.records {
+		n, _ := strconv.Atoi(id)
+		ids = append(ids, n)
+	}
+	sort.Ints(ids)
+	records := []map[string]any{}
+	for _, id := range ids {
+		records = append(records, f.records[strconv.Itoa(id)])
+	}
+	return records
+}
+
+func fakeRecord(name string, type_ string, content string, ttl int64) map[string]any {
+	return map[string]any{
+		"name":    name,
+		"type":    type_,
+		"content": content,
+		"ttl":     fmt.Sprint(ttl),
+		"prio":    "0",
+		"notes":   "",
+	}
+}
+
+func zoneRecord(subdomain string, type_ string, content string) zoneRecordModel {
+	return zoneRecordModel{
+		Subdomain: types.StringValue(subdomain),
+		Type:      types.StringValue(type_),
+		Content:   types.StringValue(content),
+		TTL:       types.Int64Null(),
+		Priority:  types.Int64Null(),
+	}
+}
+
+// ignoreFilter returns an ignore filter, where an empty type means any type
+// and an empty subdomain is the apex, unless both are empty.
+func ignoreFilter(type_ string, subdomain string) zoneIgnoreModel {
+	filter := zoneIgnoreModel{Type: types.StringNull(), Subdomain: types.StringNull()}
+	if type_ != "" {
+		filter.Type = types.StringValue(type_)
+	}
+	if subdomain != "" || type_ == "" {
+		filter.Subdomain = types.StringValue(subdomain)
+	}
+	return filter
+}
@@ -0,0 +1,416 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &DomainResource{}
+var _ resource.ResourceWithImportState = &DomainResource{}
+var _ resource.ResourceWithValidateConfig = &DomainResource{}
+
+type DomainResource struct {
+	client *porkbun.Client
+}
+
+type DomainResourceModel struct {
+	Domain       types.String  `tfsdk:"domain"`
+	MaxPrice     types.Float64 `tfsdk:"max_price"`
+	AgreeToTerms types.Bool    `tfsdk:"agree_to_terms"`
+	Price        types.Float64 `tfsdk:"price"`
+	OrderID      types.String  `tfsdk:"order_id"`
+	Status       types.String  `tfsdk:"status"`
+	TLD          types.String  `tfsdk:"tld"`
+	CreateDate   types.String  `tfsdk:"create_date"`
+	ExpireDate   types.String  `tfsdk:"expire_date"`
+}
+
+func NewDomainResource() resource.Resource {
+	return &DomainResource{}
+}
+
+func (r *DomainResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+func (r *DomainResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Register a domain for its minimum duration, charged to the account balance. " +
+			"Registrations can't be undone, so destroying this resource only removes it from the state. " +
+			"A domain that is no longer in the account, e.g. because it expired, is removed from the state " +
+			"and planned for registration again.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The domain to register.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_price": schema.Float64Attribute{
+				Required: true,
+				MarkdownDescription: "The maximum price in USD you're willing to pay for the registration. " +
+					"The domain isn't registered if its current price, including premium pricing, exceeds it.",
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			"agree_to_terms": schema.BoolAttribute{
+				Required: true,
+				MarkdownDescription: "Must be true to agree to Porkbun's terms of service for the registration, " +
+					"which is charged to the account balance.",
+			},
+			"price": schema.Float64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The price in USD paid for the registration.",
+				PlanModifiers: []planmodifier.Float64{
+					float64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"order_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the registration order.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the domain.",
+			},
+			"tld": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The top level domain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"create_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date the domain was registered.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expire_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date the domain expires.",
+			},
+		},
+	}
+}
+
+func (r *DomainResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DomainResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var agreeToTerms types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("agree_to_terms"), &agreeToTerms)...)
+	if resp.Diagnostics.HasError() || agreeToTerms.IsNull() || agreeToTerms.IsUnknown() {
+		return
+	}
+
+	if !agreeToTerms.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("agree_to_terms"),
+			"Terms Not Agreed To",
+			"Porkbun only registers domains after agreeing to its terms of service, "+
+				"set agree_to_terms to true to agree to them and pay for the registration.",
+		)
+	}
+}
+
+func (r *DomainResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	// The price is checked right before the registration, as it may have
+	// changed since the plan, e.g. when a promotion ended.
+	availability, err := r.client.CheckDomain(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+	if availability.Avail != "yes" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain"),
+			"Domain Not Available",
+			fmt.Sprintf("The domain '%s' is not available for registration.", domain),
+		)
+		return
+	}
+
+	price, err := parsePrice(availability.Price)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
+			"Failed to parse price as a number with the following error: '%s'.",
+			err.Error(),
+		))
+		return
+	}
+	years := int64(1)
+	if availability.MinDuration != "" {
+		years, err = availability.MinDuration.Int64()
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
+				"Failed to parse minimum duration as an integer with the following error: '%s'.",
+				err.Error(),
+			))
+			return
+		}
+		years = max(years, 1)
+	}
+	cost := int64(math.Round(price*100)) * years
+
+	if float64(cost)/100 > model.MaxPrice.ValueFloat64() {
+		premium := ""
+		if availability.Premium == "yes" {
+			premium = " The domain has premium pricing."
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_price"),
+			"Domain Price Exceeds Maximum",
+			fmt.Sprintf(
+				"The registration of '%s' costs %.2f USD for %d year(s), "+
+					"which exceeds the maximum price of %.2f USD.%s",
+				domain,
+				float64(cost)/100,
+				years,
+				model.MaxPrice.ValueFloat64(),
+				premium,
+			),
+		)
+		return
+	}
+
+	model.Price = types.Float64Value(float64(cost) / 100)
+
+	orderID, err := r.client.CreateDomain(ctx, domain, cost, model.AgreeToTerms.ValueBool())
+	if err != nil {
+		// The registration may have gone through even though the response
+		// was lost, so the domain is kept in the state if it's in the account
+		// now, rather than planning to buy it again.
+		registered, errs := r.client.Domain(ctx, domain)
+		if len(errs) != 0 {
+			addClientError(&resp.Diagnostics, err)
+			return
+		}
+		resp.Diagnostics.AddWarning("Domain Registration Failed", fmt.Sprintf(
+			"The registration of '%s' failed with the following error, "+
+				"but the domain is in the account, so it was most likely registered anyway: '%s'.",
+			domain,
+			err.Error(),
+		))
+		model.OrderID = types.StringNull()
+		domainToModel(registered, &model)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
+	model.OrderID = types.StringValue(orderID)
+
+	// The domain may take a moment to show up in the account, the status and
+	// dates are filled in by the next refresh in that case.
+	registered, errs := r.client.Domain(ctx, domain)
+	if len(errs) != 0 {
+		model.Status = types.StringNull()
+		model.TLD = types.StringNull()
+		model.CreateDate = types.StringNull()
+		model.ExpireDate = types.StringNull()
+		resp.Diagnostics.AddWarning("Domain Not Read", fmt.Sprintf(
+			"The domain '%s' was registered, but reading it back failed with the following error: '%s'.",
+			domain,
+			errors.Join(errs...).Error(),
+		))
+	} else {
+		domainToModel(registered, &model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	registered, errs := r.client.Domain(ctx, domain)
+	if len(errs) == 1 && errors.Is(errs[0], porkbun.ErrDomainNotFound) {
+		resp.Diagnostics.AddWarning("Domain Not Found", fmt.Sprintf(
+			"The domain '%s' is no longer in the account, "+
+				"most likely it expired or was transferred away. "+
+				"It has been removed from the state and will be planned for registration again, "+
+				"up to the maximum price. Remove the resource from the configuration to stop managing it.",
+			domain,
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	domainToModel(registered, &model)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	// Only max_price and agree_to_terms can change in place and they don't
+	// affect the registration once made.
+	var model DomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state DomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Status = state.Status
+	model.ExpireDate = state.ExpireDate
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model DomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning("Domain Not Deleted", fmt.Sprintf(
+		"Registrations can't be undone, so the domain '%s' has only been removed from the state. "+
+			"It stays in the account until it expires, disable auto renew to let it lapse.",
+		model.Domain.ValueString(),
+	))
+}
+
+func (r *DomainResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	// The maximum price isn't known to Porkbun, so it can be given after the
+	// domain to keep the first plan clean, e.g. 'example.com/15.00'.
+	domain, maxPrice, found := strings.Cut(req.ID, "/")
+	if domain == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain' or 'domain/max_price', got: '%s'.",
+			req.ID,
+		))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	// The terms were agreed to when the domain was registered.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("agree_to_terms"), true)...)
+	if !found {
+		resp.Diagnostics.AddWarning("Maximum Price Not Imported", fmt.Sprintf(
+			"The import identifier has no maximum price, so the next plan updates max_price in place, "+
+				"which doesn't change the registration. Import with '%s/<max_price>' to avoid it.",
+			domain,
+		))
+		return
+	}
+	price, err := strconv.ParseFloat(maxPrice, 64)
+	if err != nil || price < 0 {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected a non-negative number as the maximum price, got: '%s'.",
+			maxPrice,
+		))
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("max_price"), price)...)
+}
+
+func domainToModel(domain *porkbun.Domain, model *DomainResourceModel) {
+	model.Status = types.StringValue(domain.Status)
+	model.TLD = types.StringValue(domain.TLD)
+	model.CreateDate = types.StringValue(domain.CreateDate)
+	model.ExpireDate = types.StringValue(domain.ExpireDate)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &DomainAutoRenewResource{}
+var _ resource.ResourceWithImportState = &DomainAutoRenewResource{}
+
+type DomainAutoRenewResource struct {
+	client *porkbun.Client
+}
+
+type DomainAutoRenewResourceModel struct {
+	Domain  types.String `tfsdk:"domain"`
+	Enabled types.Bool   `tfsdk:"enabled"`
+}
+
+func NewDomainAutoRenewResource() resource.Resource {
+	return &DomainAutoRenewResource{}
+}
+
+func (r *DomainAutoRenewResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_auto_renew"
+}
+
+func (r *DomainAutoRenewResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Turn auto renew on or off for your domain. " +
+			"Destroying this resource leaves the setting as it is.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether the domain renews automatically.",
+			},
+		},
+	}
+}
+
+func (r *DomainAutoRenewResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DomainAutoRenewResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DomainAutoRenewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	errs := r.client.UpdateAutoRenew(ctx, model.Enabled.ValueBool(), []string{domain})
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DomainAutoRenewResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	d, errs := r.client.Domain(ctx, domain)
+	if len(errs) == 1 && errors.Is(errs[0], porkbun.ErrDomainNotFound) {
+		resp.Diagnostics.AddWarning("Domain Not Found", fmt.Sprintf(
+			"The domain '%s' is no longer in the account. "+
+				"It has been removed from the state and will be planned for creation.",
+			domain,
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	model.Enabled = types.BoolValue(d.AutoRenew)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var model DomainAutoRenewResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	errs := r.client.UpdateAutoRenew(ctx, model.Enabled.ValueBool(), []string{domain})
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// The setting is left as it is, there's no default to go back to.
+}
+
+func (r *DomainAutoRenewResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain"), req, resp)
+}
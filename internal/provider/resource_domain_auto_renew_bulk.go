@@ -0,0 +1,224 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &DomainAutoRenewBulkResource{}
+
+type DomainAutoRenewBulkResource struct {
+	client *porkbun.Client
+}
+
+type DomainAutoRenewBulkResourceModel struct {
+	Domains types.Set  `tfsdk:"domains"`
+	Enabled types.Bool `tfsdk:"enabled"`
+}
+
+func NewDomainAutoRenewBulkResource() resource.Resource {
+	return &DomainAutoRenewBulkResource{}
+}
+
+func (r *DomainAutoRenewBulkResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_auto_renew_bulk"
+}
+
+func (r *DomainAutoRenewBulkResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Turn auto renew on or off for a set of domains in a single request. " +
+			"Domains removed from the set, or the set as a whole on destroy, keep their setting as it is.",
+		Attributes: map[string]schema.Attribute{
+			"domains": schema.SetAttribute{
+				Required:            true,
+				MarkdownDescription: "A set of your domains.",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 253),
+					),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Required:            true,
+				MarkdownDescription: "Whether the domains renew automatically.",
+			},
+		},
+	}
+}
+
+func (r *DomainAutoRenewBulkResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DomainAutoRenewBulkResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DomainAutoRenewBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewBulkResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DomainAutoRenewBulkResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []string
+	resp.Diagnostics.Append(model.Domains.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domains, errs := r.client.Domains(ctx)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+	autoRenew := map[string]bool{}
+	for _, domain := range domains {
+		autoRenew[strings.ToLower(domain.Domain)] = domain.AutoRenew
+	}
+
+	// Domains that drifted are dropped from the state, so that the next plan
+	// adds them back and the update sets auto renew for them again.
+	kept := []string{}
+	missing := []string{}
+	for _, name := range names {
+		enabled, ok := autoRenew[strings.ToLower(name)]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+		if enabled == model.Enabled.ValueBool() {
+			kept = append(kept, name)
+		}
+	}
+	if len(missing) != 0 {
+		sort.Strings(missing)
+		resp.Diagnostics.AddWarning("Domains Not Found", fmt.Sprintf(
+			"The following domains are no longer in the account and have been removed from the state: '%s'.",
+			strings.Join(missing, "', '"),
+		))
+	}
+
+	set, diags := types.SetValueFrom(ctx, types.StringType, kept)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.Domains = set
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewBulkResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var model DomainAutoRenewBulkResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.update(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainAutoRenewBulkResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// The setting is left as it is, there's no default to go back to.
+}
+
+// update sets auto renew for every domain in the set. Sending the domains that
+// already have it set is harmless and keeps it to a single request.
+func (r *DomainAutoRenewBulkResource) update(
+	ctx context.Context,
+	model *DomainAutoRenewBulkResourceModel,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	var domains []string
+	diags.Append(model.Domains.ElementsAs(ctx, &domains, false)...)
+	if diags.HasError() {
+		return diags
+	}
+	sort.Strings(domains)
+
+	errs := r.client.UpdateAutoRenew(ctx, model.Enabled.ValueBool(), domains)
+	for _, err := range errs {
+		addClientError(&diags, err)
+	}
+	return diags
+}
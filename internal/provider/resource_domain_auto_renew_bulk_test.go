@@ -0,0 +1,134 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+func TestDomainAutoRenewBulkRead(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		domain := func(name string, autoRenew string) map[string]string {
+			return map[string]string{
+				"domain":       name,
+				"status":       "ACTIVE",
+				"TLD":          name[strings.LastIndex(name, ".")+1:],
+				"securityLock": "1",
+				"whoisPrivacy": "1",
+				"autoRenew":    autoRenew,
+				"notLocal":     "0",
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "SUCCESS",
+			"domains": []map[string]string{
+				domain("example.com", "1"),
+				domain("example.net", "0"),
+				domain("example.org", "1"),
+				domain("example.dev", "0"),
+			},
+		})
+	}))
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &DomainAutoRenewBulkResource{
+		client: porkbun.NewClient(server.Client(), baseURL, "pk1_test", "sk1_test", nil),
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	tests := []struct {
+		name    string
+		enabled bool
+		domains []string
+		kept    []string
+		missing string
+	}{
+		{
+			name:    "unchanged",
+			enabled: true,
+			domains: []string{"example.com", "Example.org"},
+			kept:    []string{"example.com", "Example.org"},
+		},
+		{
+			name:    "drifted",
+			enabled: true,
+			domains: []string{"example.com", "example.net", "example.org"},
+			kept:    []string{"example.com", "example.org"},
+		},
+		{
+			name:    "disabled drifted",
+			enabled: false,
+			domains: []string{"example.com", "example.net", "example.dev"},
+			kept:    []string{"example.net", "example.dev"},
+		},
+		{
+			name:    "missing",
+			enabled: true,
+			domains: []string{"example.com", "gone.example", "missing.example"},
+			kept:    []string{"example.com"},
+			missing: "'gone.example', 'missing.example'",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			domains, diags := types.SetValueFrom(ctx, types.StringType, test.domains)
+			state := tfsdk.State{Schema: s, Raw: null}
+			diags.Append(state.Set(ctx, &DomainAutoRenewBulkResourceModel{
+				Domains: domains,
+				Enabled: types.BoolValue(test.enabled),
+			})...)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			resp := &resource.ReadResponse{State: state}
+			r.Read(ctx, resource.ReadRequest{State: state}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var got DomainAutoRenewBulkResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			want, diags := types.SetValueFrom(ctx, types.StringType, test.kept)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			if !got.Domains.Equal(want) {
+				t.Errorf("kept domains %s, want %s", got.Domains, want)
+			}
+
+			warnings := resp.Diagnostics.Warnings()
+			switch {
+			case test.missing == "" && len(warnings) != 0:
+				t.Errorf("got warnings %v, want none", warnings)
+			case test.missing != "" && (len(warnings) != 1 || !strings.Contains(warnings[0].Detail(), test.missing)):
+				t.Errorf("got warnings %v, want one listing %s", warnings, test.missing)
+			}
+		})
+	}
+}
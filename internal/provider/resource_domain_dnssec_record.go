@@ -0,0 +1,433 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &DomainDNSSECRecordResource{}
+var _ resource.ResourceWithImportState = &DomainDNSSECRecordResource{}
+
+type DomainDNSSECRecordResource struct {
+	client *porkbun.Client
+}
+
+type DomainDNSSECRecordResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	Domain           types.String `tfsdk:"domain"`
+	KeyTag           types.Int64  `tfsdk:"key_tag"`
+	Algorithm        types.Int64  `tfsdk:"algorithm"`
+	DigestType       types.Int64  `tfsdk:"digest_type"`
+	Digest           types.String `tfsdk:"digest"`
+	MaxSigLife       types.Int64  `tfsdk:"max_sig_life"`
+	KeyDataFlags     types.Int64  `tfsdk:"key_data_flags"`
+	KeyDataProtocol  types.Int64  `tfsdk:"key_data_protocol"`
+	KeyDataAlgorithm types.Int64  `tfsdk:"key_data_algorithm"`
+	KeyDataPublicKey types.String `tfsdk:"key_data_public_key"`
+}
+
+func NewDomainDNSSECRecordResource() resource.Resource {
+	return &DomainDNSSECRecordResource{}
+}
+
+func (r *DomainDNSSECRecordResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_dnssec_record"
+}
+
+func (r *DomainDNSSECRecordResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create a DNSSEC DS record for your domain at the registry. " +
+			"Any change replaces the record, as Porkbun doesn't support editing them, " +
+			"except for setting the key data and maximum signature life of an imported record " +
+			"when Porkbun doesn't return them.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the record in the form 'domain/key_tag'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_tag": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The key tag of the DNSKEY the record refers to.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"algorithm": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The DS data algorithm number, e.g. 13 for ECDSA P-256 with SHA-256.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"digest_type": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: "The digest type number, e.g. 2 for SHA-256.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"digest": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The hexadecimal digest of the DNSKEY.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(hexRE, "must be a hexadecimal string"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"max_sig_life": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The maximum signature life in seconds.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImportedInt64,
+						requiresReplaceUnlessImportedDescription,
+						requiresReplaceUnlessImportedDescription,
+					),
+				},
+			},
+			"key_data_flags": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The flags of the key data, e.g. 257 for a key signing key.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 65535),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImportedInt64,
+						requiresReplaceUnlessImportedDescription,
+						requiresReplaceUnlessImportedDescription,
+					),
+				},
+			},
+			"key_data_protocol": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The protocol of the key data, always 3.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImportedInt64,
+						requiresReplaceUnlessImportedDescription,
+						requiresReplaceUnlessImportedDescription,
+					),
+				},
+			},
+			"key_data_algorithm": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "The algorithm number of the key data.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 255),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImportedInt64,
+						requiresReplaceUnlessImportedDescription,
+						requiresReplaceUnlessImportedDescription,
+					),
+				},
+			},
+			"key_data_public_key": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The base64 encoded public key of the key data.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceUnlessImportedString,
+						requiresReplaceUnlessImportedDescription,
+						requiresReplaceUnlessImportedDescription,
+					),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainDNSSECRecordResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DomainDNSSECRecordResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DomainDNSSECRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	err := r.client.CreateDNSSECRecord(ctx, domain, &porkbun.DNSSECRecord{
+		KeyTag:           model.KeyTag.ValueInt64(),
+		Algorithm:        model.Algorithm.ValueInt64(),
+		DigestType:       model.DigestType.ValueInt64(),
+		Digest:           model.Digest.ValueString(),
+		MaxSigLife:       model.MaxSigLife.ValueInt64Pointer(),
+		KeyDataFlags:     model.KeyDataFlags.ValueInt64Pointer(),
+		KeyDataProtocol:  model.KeyDataProtocol.ValueInt64Pointer(),
+		KeyDataAlgorithm: model.KeyDataAlgorithm.ValueInt64Pointer(),
+		KeyDataPublicKey: model.KeyDataPublicKey.ValueString(),
+	})
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	model.ID = types.StringValue(domain + "/" + strconv.FormatInt(model.KeyTag.ValueInt64(), 10))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainDNSSECRecordResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DomainDNSSECRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	keyTag := model.KeyTag.ValueInt64()
+
+	records, errs := r.client.DNSSECRecords(ctx, domain)
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	var record *porkbun.DNSSECRecord
+	for _, r := range records {
+		if r.KeyTag == keyTag {
+			record = r
+		}
+	}
+	if record == nil {
+		resp.Diagnostics.AddWarning("DNSSEC Record Not Found", fmt.Sprintf(
+			"DNSSEC record with the key tag '%d' no longer exists on '%s', "+
+				"most likely it was deleted outside of Terraform. "+
+				"It has been removed from the state and will be planned for creation.",
+			keyTag,
+			domain,
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Porkbun only returns the key data and maximum signature life if they
+	// were set, otherwise they're kept as configured.
+	model.Algorithm = types.Int64Value(record.Algorithm)
+	model.DigestType = types.Int64Value(record.DigestType)
+	if !strings.EqualFold(model.Digest.ValueString(), record.Digest) {
+		model.Digest = types.StringValue(record.Digest)
+	}
+	if record.MaxSigLife != nil {
+		model.MaxSigLife = types.Int64PointerValue(record.MaxSigLife)
+	}
+	if record.KeyDataFlags != nil {
+		model.KeyDataFlags = types.Int64PointerValue(record.KeyDataFlags)
+	}
+	if record.KeyDataProtocol != nil {
+		model.KeyDataProtocol = types.Int64PointerValue(record.KeyDataProtocol)
+	}
+	if record.KeyDataAlgorithm != nil {
+		model.KeyDataAlgorithm = types.Int64PointerValue(record.KeyDataAlgorithm)
+	}
+	if record.KeyDataPublicKey != "" {
+		model.KeyDataPublicKey = types.StringValue(record.KeyDataPublicKey)
+	}
+	model.ID = types.StringValue(domain + "/" + strconv.FormatInt(keyTag, 10))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainDNSSECRecordResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	// The record itself requires replacement, only the key data and maximum
+	// signature life that Porkbun didn't return are filled in after import.
+	var model DomainDNSSECRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// From now on the values are known, so changing them replaces the record.
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, dnssecImportedKey, nil)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainDNSSECRecordResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model DomainDNSSECRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	keyTag := model.KeyTag.ValueInt64()
+
+	err := r.client.DeleteDNSSECRecord(ctx, domain, keyTag)
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+func (r *DomainDNSSECRecordResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	domain, keyTag, ok := strings.Cut(req.ID, "/")
+	if !ok || domain == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain/key_tag', got: '%s'.",
+			req.ID,
+		))
+		return
+	}
+
+	tag, err := strconv.ParseInt(keyTag, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Failed to parse key tag as an integer with the following error: '%s'.",
+			err.Error(),
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key_tag"), tag)...)
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, dnssecImportedKey, []byte("true"))...)
+}
+
+// dnssecImportedKey is the private state key marking a record as imported,
+// so that the attributes Porkbun didn't return are still unknown.
+const dnssecImportedKey = "imported"
+
+// requiresReplaceUnlessImportedDescription describes the plan modifiers of
+// the attributes Porkbun may not return, which stay null after import then.
+const requiresReplaceUnlessImportedDescription = "Changing the value requires replacement, " +
+	"unless it is set for the first time after the record was imported."
+
+func requiresReplaceUnlessImportedInt64(
+	ctx context.Context,
+	req planmodifier.Int64Request,
+	resp *int64planmodifier.RequiresReplaceIfFuncResponse,
+) {
+	resp.RequiresReplace, resp.Diagnostics = requiresReplaceUnlessImported(ctx, req.StateValue.IsNull(), req.Private)
+}
+
+func requiresReplaceUnlessImportedString(
+	ctx context.Context,
+	req planmodifier.StringRequest,
+	resp *stringplanmodifier.RequiresReplaceIfFuncResponse,
+) {
+	resp.RequiresReplace, resp.Diagnostics = requiresReplaceUnlessImported(ctx, req.StateValue.IsNull(), req.Private)
+}
+
+// requiresReplaceUnlessImported reports whether a change requires
+// replacement, which it doesn't if the value is only being filled in for an
+// imported record.
+func requiresReplaceUnlessImported(
+	ctx context.Context,
+	stateNull bool,
+	private interface {
+		GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	},
+) (
+	bool,
+	diag.Diagnostics,
+) {
+	if !stateNull {
+		return true, nil
+	}
+	imported, diags := private.GetKey(ctx, dnssecImportedKey)
+	return imported == nil, diags
+}
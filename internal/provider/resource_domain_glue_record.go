@@ -0,0 +1,320 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &DomainGlueRecordResource{}
+var _ resource.ResourceWithImportState = &DomainGlueRecordResource{}
+var _ resource.ResourceWithValidateConfig = &DomainGlueRecordResource{}
+
+type DomainGlueRecordResource struct {
+	client *porkbun.Client
+}
+
+type DomainGlueRecordResourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+	Host   types.String `tfsdk:"host"`
+	IPs    types.Set    `tfsdk:"ips"`
+}
+
+func NewDomainGlueRecordResource() resource.Resource {
+	return &DomainGlueRecordResource{}
+}
+
+func (r *DomainGlueRecordResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_domain_glue_record"
+}
+
+func (r *DomainGlueRecordResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Create a glue record at the registry for a name server under your domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the glue record in the form 'domain/host'.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"host": schema.StringAttribute{
+				Required: true,
+				MarkdownDescription: "The host name of the name server, relative to the domain, e.g. 'ns1'. " +
+					"Fully qualified names, e.g. 'ns1.example.com', are also accepted.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"ips": schema.SetAttribute{
+				Required:            true,
+				MarkdownDescription: "A set of IPv4 and IPv6 addresses of the name server.",
+				ElementType:         types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainGlueRecordResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *DomainGlueRecordResource) ValidateConfig(
+	ctx context.Context,
+	req resource.ValidateConfigRequest,
+	resp *resource.ValidateConfigResponse,
+) {
+	var ips types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ips"), &ips)...)
+	if resp.Diagnostics.HasError() || ips.IsNull() || ips.IsUnknown() {
+		return
+	}
+
+	for _, element := range ips.Elements() {
+		ip, ok := element.(types.String)
+		if !ok || ip.IsNull() || ip.IsUnknown() {
+			continue
+		}
+		addr, err := netip.ParseAddr(ip.ValueString())
+		if err != nil || addr.Zone() != "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ips").AtSetValue(ip),
+				"Invalid IP Address",
+				fmt.Sprintf("Expected an IPv4 or IPv6 address, got: '%s'.", ip.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *DomainGlueRecordResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model DomainGlueRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	host := model.Host.ValueString()
+
+	var ips []string
+	resp.Diagnostics.Append(model.IPs.ElementsAs(ctx, &ips, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.CreateGlueRecord(ctx, domain, host, ips)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	model.ID = types.StringValue(domain + "/" + porkbun.RelativeName(host, domain))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainGlueRecordResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model DomainGlueRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	host := model.Host.ValueString()
+
+	record, errs := r.client.GlueRecord(ctx, domain, host)
+	if len(errs) == 1 && errors.Is(errs[0], porkbun.ErrNotFound) {
+		resp.Diagnostics.AddWarning("Glue Record Not Found", fmt.Sprintf(
+			"Glue record for the host '%s' no longer exists, "+
+				"most likely it was deleted outside of Terraform. "+
+				"It has been removed from the state and will be planned for creation.",
+			porkbun.FQDN(host, domain),
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if errs != nil && len(errs) != 0 {
+		for _, err := range errs {
+			addClientError(&resp.Diagnostics, err)
+		}
+		return
+	}
+
+	// Keep the addresses as written in the state when they're equal to the
+	// ones returned, e.g. an uncompressed IPv6 address.
+	var known []string
+	resp.Diagnostics.Append(model.IPs.ElementsAs(ctx, &known, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ips := []attr.Value{}
+	for _, ip := range record.IPs {
+		for _, k := range known {
+			if sameIP(ip, k) {
+				ip = k
+				break
+			}
+		}
+		ips = append(ips, types.StringValue(ip))
+	}
+	set, diags := types.SetValue(types.StringType, ips)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.IPs = set
+	model.ID = types.StringValue(domain + "/" + porkbun.RelativeName(host, domain))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainGlueRecordResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var model DomainGlueRecordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	host := model.Host.ValueString()
+
+	var ips []string
+	resp.Diagnostics.Append(model.IPs.ElementsAs(ctx, &ips, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.UpdateGlueRecord(ctx, domain, host, ips)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *DomainGlueRecordResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model DomainGlueRecordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+	host := model.Host.ValueString()
+
+	err := r.client.DeleteGlueRecord(ctx, domain, host)
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+func (r *DomainGlueRecordResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	domain, host, ok := strings.Cut(req.ID, "/")
+	if !ok || domain == "" || host == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain/host', got: '%s'.",
+			req.ID,
+		))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("host"), host)...)
+}
+
+func sameIP(a string, b string) bool {
+	x, err := netip.ParseAddr(a)
+	if err != nil {
+		return false
+	}
+	y, err := netip.ParseAddr(b)
+	if err != nil {
+		return false
+	}
+	return x == y
+}
@@ -5,13 +5,23 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -21,17 +31,42 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var _ resource.Resource = &DomainNameServersResource{}
+var _ resource.ResourceWithImportState = &DomainNameServersResource{}
+var _ resource.ResourceWithUpgradeState = &DomainNameServersResource{}
+var _ resource.ResourceWithModifyPlan = &DomainNameServersResource{}
 
 type DomainNameServersResource struct {
-	deleteNameServers bool
 	client            *porkbun.Client
+	deleteNameServers bool
 }
 
 type DomainNameServersResourceModel struct {
-	Domain types.String `tfsdk:"domain"`
-	NS     types.List   `tfsdk:"ns"`
+	Domain             types.String `tfsdk:"domain"`
+	NS                 types.Set    `tfsdk:"ns"`
+	OnDestroy          types.String `tfsdk:"on_destroy"`
+	VerifyBeforeUpdate types.Bool   `tfsdk:"verify_before_update"`
+	VerifyResolver     types.String `tfsdk:"verify_resolver"`
+	VerifyPort         types.Int64  `tfsdk:"verify_port"`
+}
+
+// DomainNameServersResourceModelV0 is the model of schema version 0, where
+// the name servers were a list.
+type DomainNameServersResourceModelV0 struct {
+	Domain    types.String `tfsdk:"domain"`
+	NS        types.List   `tfsdk:"ns"`
+	OnDestroy types.String `tfsdk:"on_destroy"`
 }
 
+// previousNameServersKey is the private state key of the name servers that
+// were in place before the resource was created or imported.
+const previousNameServersKey = "previous_name_servers"
+
+const (
+	onDestroyKeep            = "keep"
+	onDestroyRestorePrevious = "restore_previous"
+	onDestroyPorkbunDefault  = "porkbun_default"
+)
+
 func NewDomainNameServersResource() resource.Resource {
 	return &DomainNameServersResource{}
 }
@@ -50,7 +85,9 @@ func (r *DomainNameServersResource) Schema(
 	resp *resource.SchemaResponse,
 ) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Update the name servers for your domain.",
+		Version: 1,
+		MarkdownDescription: "Update the name servers for your domain. " +
+			"The name servers in place before are remembered, so that they can be restored on destroy.",
 		Attributes: map[string]schema.Attribute{
 			"domain": schema.StringAttribute{
 				Required:            true,
@@ -62,17 +99,62 @@ func (r *DomainNameServersResource) Schema(
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
-			"ns": schema.ListAttribute{
-				Required:            true,
-				MarkdownDescription: "An array of name servers that you would like to update your domain with.",
-				ElementType:         types.StringType,
-				Validators: []validator.List{
-					listvalidator.UniqueValues(),
-					listvalidator.ValueStringsAre(
+			"ns": schema.SetAttribute{
+				Required: true,
+				MarkdownDescription: "A set of name servers that you would like to update your domain with. " +
+					"Host names are compared ignoring case and the trailing dot.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(2),
+					setvalidator.ValueStringsAre(
 						stringvalidator.LengthBetween(1, 253),
+						stringvalidator.RegexMatches(hostnameRE, "must be a host name"),
+					),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "What to do with the name servers on destroy: " +
+					"'keep' leaves them as they are, " +
+					"'restore_previous' restores the ones in place before the resource was created or imported and " +
+					"'porkbun_default' sets Porkbun's own name servers. Defaults to 'keep', " +
+					"or 'porkbun_default' if the deprecated `delete_name_servers` of the provider is true.",
+				Default: stringdefault.StaticString(onDestroyKeep),
+				Validators: []validator.String{
+					stringvalidator.OneOf(
+						onDestroyKeep,
+						onDestroyRestorePrevious,
+						onDestroyPorkbunDefault,
 					),
 				},
 			},
+			"verify_before_update": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Query each name server for the SOA record of the domain before updating, " +
+					"and fail unless every address of them that responds answers authoritatively, over UDP or TCP. " +
+					"Addresses that don't respond only produce a warning if another address of the name server answered. " +
+					"Defaults to false.",
+				Default: booldefault.StaticBool(false),
+			},
+			"verify_resolver": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "The address of the DNS server used to resolve the name servers for verification, " +
+					"in the form 'host:port'. Defaults to the system resolver.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"verify_port": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The port the name servers are queried on for verification. Defaults to 53.",
+				Default:             int64default.StaticInt64(53),
+				Validators: []validator.Int64{
+					int64validator.Between(1, 65535),
+				},
+			},
 		},
 	}
 }
@@ -96,8 +178,29 @@ func (r *DomainNameServersResource) Configure(
 		return
 	}
 
-	r.deleteNameServers = data.DeleteNameServers
 	r.client = data.Client
+	r.deleteNameServers = data.DeleteNameServers
+}
+
+// ModifyPlan keeps honoring the deprecated delete_name_servers of the
+// provider, which restores Porkbun's default name servers on destroy unless
+// on_destroy is configured.
+func (r *DomainNameServersResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	if req.Plan.Raw.IsNull() || !r.deleteNameServers {
+		return
+	}
+
+	var onDestroy types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("on_destroy"), &onDestroy)...)
+	if resp.Diagnostics.HasError() || !onDestroy.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("on_destroy"), onDestroyPorkbunDefault)...)
 }
 
 func (r *DomainNameServersResource) Create(
@@ -119,13 +222,25 @@ func (r *DomainNameServersResource) Create(
 		return
 	}
 
-	err := r.client.UpdateNameServers(ctx, domain, ns)
+	resp.Diagnostics.Append(r.verify(ctx, &model, ns)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous, err := r.client.NameServers(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	err = r.client.UpdateNameServers(ctx, domain, ns)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+	resp.Diagnostics.Append(setPreviousNameServers(ctx, resp.Private, previous)...)
 }
 
 func (r *DomainNameServersResource) Read(
@@ -143,11 +258,28 @@ func (r *DomainNameServersResource) Read(
 
 	servers, err := r.client.NameServers(ctx, domain)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+
+	// Keep the host names as written in the state when they only differ in
+	// case or the trailing dot.
+	var known []string
+	resp.Diagnostics.Append(model.NS.ElementsAs(ctx, &known, false)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	for i, server := range servers {
+		servers[i] = canonicalHostname(server)
+		for _, k := range known {
+			if canonicalHostname(k) == servers[i] {
+				servers[i] = k
+				break
+			}
+		}
+	}
 
-	ns, diags := types.ListValueFrom(ctx, types.StringType, servers)
+	ns, diags := types.SetValueFrom(ctx, types.StringType, servers)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -170,15 +302,33 @@ func (r *DomainNameServersResource) Update(
 
 	domain := model.Domain.ValueString()
 
+	var state DomainNameServersResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only on_destroy or the verification settings changed, which don't
+	// touch the name servers.
+	if model.NS.Equal(state.NS) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+		return
+	}
+
 	var ns []string
 	resp.Diagnostics.Append(model.NS.ElementsAs(ctx, &ns, false)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	resp.Diagnostics.Append(r.verify(ctx, &model, ns)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.UpdateNameServers(ctx, domain, ns)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -190,10 +340,6 @@ func (r *DomainNameServersResource) Delete(
 	req resource.DeleteRequest,
 	resp *resource.DeleteResponse,
 ) {
-	if !r.deleteNameServers {
-		return
-	}
-
 	var model DomainNameServersResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
 	if resp.Diagnostics.HasError() {
@@ -201,11 +347,207 @@ func (r *DomainNameServersResource) Delete(
 	}
 
 	domain := model.Domain.ValueString()
-	ns := []string{}
+
+	var ns []string
+	switch model.OnDestroy.ValueString() {
+	case onDestroyRestorePrevious:
+		previous, diags := req.Private.GetKey(ctx, previousNameServersKey)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if previous != nil {
+			err := json.Unmarshal(previous, &ns)
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
+					"Failed to unmarshal previous name servers as JSON with the following error: '%s'.",
+					err.Error(),
+				))
+				return
+			}
+		}
+		if len(ns) == 0 {
+			resp.Diagnostics.AddWarning("Previous Name Servers Unknown", fmt.Sprintf(
+				"The name servers of '%s' in place before Terraform took over are unknown, "+
+					"most likely the resource was created before on_destroy was supported. "+
+					"The name servers have been kept as they are.",
+				domain,
+			))
+			return
+		}
+	case onDestroyPorkbunDefault:
+		ns = porkbun.DefaultNameServers
+	default:
+		return
+	}
 
 	err := r.client.UpdateNameServers(ctx, domain, ns)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+func (r *DomainNameServersResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	domain := req.ID
+
+	// The name servers in place at import are the ones Terraform took over.
+	previous, err := r.client.NameServers(ctx, domain)
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), onDestroyKeep)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("verify_before_update"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("verify_port"), int64(53))...)
+	resp.Diagnostics.Append(setPreviousNameServers(ctx, resp.Private, previous)...)
+}
+
+// verify checks that every name server is authoritative for the domain when
+// verify_before_update is enabled, reporting the result for each of them.
+func (r *DomainNameServersResource) verify(
+	ctx context.Context,
+	model *DomainNameServersResourceModel,
+	ns []string,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if !model.VerifyBeforeUpdate.ValueBool() {
+		return diags
+	}
+
+	domain := model.Domain.ValueString()
+	checker := newDelegationChecker(
+		model.VerifyResolver.ValueString(),
+		uint16(model.VerifyPort.ValueInt64()),
+	)
+
+	failed := false
+	report := []string{}
+	for _, server := range ns {
+		unreachable, err := checker.check(ctx, server, domain)
+		switch {
+		case err != nil:
+			failed = true
+			report = append(report, fmt.Sprintf("- %s: %s", server, err.Error()))
+		case len(unreachable) != 0:
+			report = append(report, fmt.Sprintf("- %s: authoritative, but %s", server, strings.Join(unreachable, "; ")))
+			diags.AddAttributeWarning(
+				path.Root("ns"),
+				"Name Server Partly Unreachable",
+				fmt.Sprintf(
+					"The name server '%s' answered authoritatively for '%s', "+
+						"but not every address of it could be reached to verify it:\n\n%s",
+					server,
+					domain,
+					strings.Join(unreachable, "\n"),
+				),
+			)
+		default:
+			report = append(report, fmt.Sprintf("- %s: authoritative", server))
+		}
+	}
+	if failed {
+		diags.AddAttributeError(
+			path.Root("ns"),
+			"Name Servers Not Authoritative",
+			fmt.Sprintf(
+				"Not all name servers are authoritative for '%s', so they have not been updated:\n\n%s",
+				domain,
+				strings.Join(report, "\n"),
+			),
+		)
+	}
+	return diags
+}
+
+func (r *DomainNameServersResource) UpgradeState(
+	_ context.Context,
+) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"domain": schema.StringAttribute{
+						Required: true,
+					},
+					"ns": schema.ListAttribute{
+						Required:    true,
+						ElementType: types.StringType,
+					},
+					"on_destroy": schema.StringAttribute{
+						Optional: true,
+						Computed: true,
+					},
+				},
+			},
+			StateUpgrader: func(
+				ctx context.Context,
+				req resource.UpgradeStateRequest,
+				resp *resource.UpgradeStateResponse,
+			) {
+				var prior DomainNameServersResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// The list may have repeated a name server, which a set can't.
+				elements := []attr.Value{}
+				for _, element := range prior.NS.Elements() {
+					if !slices.ContainsFunc(elements, element.Equal) {
+						elements = append(elements, element)
+					}
+				}
+				ns, diags := types.SetValue(types.StringType, elements)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				// on_destroy is missing from states written before it existed.
+				onDestroy := prior.OnDestroy
+				if onDestroy.IsNull() {
+					onDestroy = types.StringValue(onDestroyKeep)
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, DomainNameServersResourceModel{
+					Domain:             prior.Domain,
+					NS:                 ns,
+					OnDestroy:          onDestroy,
+					VerifyBeforeUpdate: types.BoolValue(false),
+					VerifyResolver:     types.StringNull(),
+					VerifyPort:         types.Int64Value(53),
+				})...)
+			},
+		},
+	}
+}
+
+// privateState is implemented by the private state of every response.
+type privateState interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+func setPreviousNameServers(
+	ctx context.Context,
+	private privateState,
+	ns []string,
+) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	value, err := json.Marshal(ns)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf(
+			"Failed to marshal previous name servers as JSON with the following error: '%s'.",
+			err.Error(),
+		))
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, previousNameServersKey, value)...)
+	return diags
 }
@@ -0,0 +1,176 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDomainNameServersDeleteNameServers(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	(&DomainNameServersResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	tests := []struct {
+		name              string
+		deleteNameServers bool
+		configured        types.String
+		want              string
+	}{
+		{"disabled", false, types.StringNull(), onDestroyKeep},
+		{"enabled", true, types.StringNull(), onDestroyPorkbunDefault},
+		{"configured keep", true, types.StringValue(onDestroyKeep), onDestroyKeep},
+		{"configured restore", true, types.StringValue(onDestroyRestorePrevious), onDestroyRestorePrevious},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ns, diags := types.SetValueFrom(ctx, types.StringType, []string{"ns1.example.net", "ns2.example.net"})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			model := DomainNameServersResourceModel{
+				Domain:             types.StringValue("example.com"),
+				NS:                 ns,
+				OnDestroy:          test.configured,
+				VerifyBeforeUpdate: types.BoolNull(),
+				VerifyResolver:     types.StringNull(),
+				VerifyPort:         types.Int64Null(),
+			}
+			// The configuration is built like a state, as it can't be set.
+			configured := tfsdk.State{Schema: s, Raw: null}
+			diags.Append(configured.Set(ctx, &model)...)
+			config := tfsdk.Config{Schema: s, Raw: configured.Raw}
+
+			// The default is applied to the plan before ModifyPlan is called.
+			if model.OnDestroy.IsNull() {
+				model.OnDestroy = types.StringValue(onDestroyKeep)
+			}
+			model.VerifyBeforeUpdate = types.BoolValue(false)
+			model.VerifyPort = types.Int64Value(53)
+			plan := tfsdk.Plan{Schema: s, Raw: null}
+			diags.Append(plan.Set(ctx, &model)...)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+
+			r := &DomainNameServersResource{deleteNameServers: test.deleteNameServers}
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+				Config: config,
+				State:  tfsdk.State{Schema: s, Raw: null},
+				Plan:   plan,
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var got types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("on_destroy"), &got)...)
+			if got.ValueString() != test.want {
+				t.Errorf("planned on_destroy = %s, want %q", got, test.want)
+			}
+		})
+	}
+
+	// Nothing is planned on destroy.
+	r := &DomainNameServersResource{deleteNameServers: true}
+	resp := &resource.ModifyPlanResponse{Plan: tfsdk.Plan{Schema: s, Raw: null}}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: s, Raw: null},
+		Plan:   tfsdk.Plan{Schema: s, Raw: null},
+	}, resp)
+	if resp.Diagnostics.HasError() || !resp.Plan.Raw.IsNull() {
+		t.Errorf("ModifyPlan() on destroy planned %v with %v", resp.Plan.Raw, resp.Diagnostics)
+	}
+}
+
+func TestDomainNameServersUpgradeStateV0(t *testing.T) {
+	ctx := context.Background()
+
+	r := &DomainNameServersResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	upgrader := r.UpgradeState(ctx)[0]
+	priorType := upgrader.PriorSchema.Type().TerraformType(ctx)
+
+	tests := []struct {
+		name      string
+		raw       string
+		onDestroy string
+	}{
+		{
+			name:      "without on_destroy",
+			raw:       `{"domain": "example.com", "ns": ["ns1.example.net", "ns2.example.net", "ns1.example.net"]}`,
+			onDestroy: onDestroyKeep,
+		},
+		{
+			name:      "null on_destroy",
+			raw:       `{"domain": "example.com", "ns": ["ns1.example.net", "ns2.example.net"], "on_destroy": null}`,
+			onDestroy: onDestroyKeep,
+		},
+		{
+			name:      "with on_destroy",
+			raw:       `{"domain": "example.com", "ns": ["ns2.example.net", "ns1.example.net"], "on_destroy": "restore_previous"}`,
+			onDestroy: onDestroyRestorePrevious,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw, err := (&tfprotov6.RawState{JSON: []byte(test.raw)}).Unmarshal(priorType)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := resource.UpgradeStateRequest{
+				State: &tfsdk.State{Schema: *upgrader.PriorSchema, Raw: raw},
+			}
+			resp := &resource.UpgradeStateResponse{
+				State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+			}
+			upgrader.StateUpgrader(ctx, req, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			var got DomainNameServersResourceModel
+			resp.Diagnostics.Append(resp.State.Get(ctx, &got)...)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+			ns, diags := types.SetValueFrom(ctx, types.StringType, []string{"ns1.example.net", "ns2.example.net"})
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			want := DomainNameServersResourceModel{
+				Domain:             types.StringValue("example.com"),
+				NS:                 ns,
+				OnDestroy:          types.StringValue(test.onDestroy),
+				VerifyBeforeUpdate: types.BoolValue(false),
+				VerifyResolver:     types.StringNull(),
+				VerifyPort:         types.Int64Value(53),
+			}
+			if !got.NS.Equal(want.NS) {
+				t.Errorf("upgraded ns = %s, want %s", got.NS, want.NS)
+			}
+			got.NS = want.NS
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("upgraded state = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
@@ -5,9 +5,13 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -20,6 +24,7 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var _ resource.Resource = &DomainURLForwardResource{}
+var _ resource.ResourceWithImportState = &DomainURLForwardResource{}
 
 type DomainURLForwardResource struct {
 	client *porkbun.Client
@@ -153,14 +158,14 @@ func (r *DomainURLForwardResource) Create(
 		Wildcard:    wildcard,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
 	forwards, errs := r.client.URLForwards(ctx, domain)
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
@@ -202,38 +207,41 @@ func (r *DomainURLForwardResource) Read(
 	}
 
 	domain := model.Domain.ValueString()
+	id := model.ID.ValueInt64()
 
-	forwards, errs := r.client.URLForwards(ctx, domain)
+	forward, errs := r.client.URLForward(ctx, domain, id)
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			if errors.Is(err, porkbun.ErrNotFound) {
+				resp.Diagnostics.AddWarning("URL Forward Not Found", fmt.Sprintf(
+					"URL forward with the id '%d' no longer exists on '%s', "+
+						"most likely it was deleted outside of Terraform. "+
+						"It has been removed from the state and will be planned for creation.",
+					id,
+					domain,
+				))
+				resp.State.RemoveResource(ctx)
+				return
+			}
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
 
-	models, diags := forwardsToModels(forwards)
+	models, diags := forwardsToModels([]*porkbun.URLForward{forward})
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	found := false
-	for _, forward := range models {
-		if model.ID.Equal(forward.ID) {
-			found = true
-			model.Subdomain = forward.Subdomain
-			model.Location = forward.Location
-			model.Type = forward.Type
-			model.IncludePath = forward.IncludePath
-			model.Wildcard = forward.Wildcard
-		}
-	}
-	if !found {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf(
-			"Failed to find url forward with the id '%d'.",
-			model.ID,
-		))
+	model.Subdomain = models[0].Subdomain
+	if forward.Subdomain == "" {
+		model.Subdomain = types.StringNull()
 	}
+	model.Location = models[0].Location
+	model.Type = models[0].Type
+	model.IncludePath = models[0].IncludePath
+	model.Wildcard = models[0].Wildcard
 	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
 }
 
@@ -253,7 +261,7 @@ func (r *DomainURLForwardResource) Update(
 
 	err := r.client.DeleteURLForward(ctx, domain, id)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
@@ -277,14 +285,14 @@ func (r *DomainURLForwardResource) Update(
 		Wildcard:    wildcard,
 	})
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+		addClientError(&resp.Diagnostics, err)
 		return
 	}
 
 	forwards, errs := r.client.URLForwards(ctx, domain)
 	if errs != nil && len(errs) != 0 {
 		for _, err := range errs {
-			resp.Diagnostics.AddError("Client Error", err.Error())
+			addClientError(&resp.Diagnostics, err)
 		}
 		return
 	}
@@ -329,8 +337,73 @@ func (r *DomainURLForwardResource) Delete(
 	id := model.ID.ValueInt64()
 
 	err := r.client.DeleteURLForward(ctx, domain, id)
-	if err != nil {
-		resp.Diagnostics.AddError("Client Error", err.Error())
+	if err != nil && !errors.Is(err, porkbun.ErrNotFound) {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+}
+
+// ImportState accepts either 'domain/id' or 'domain/subdomain', where
+// subdomain is empty for the root domain and may also be fully qualified.
+// Numeric subdomains are always treated as an id.
+func (r *DomainURLForwardResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	domain, key, ok := strings.Cut(req.ID, "/")
+	if !ok || domain == "" {
+		resp.Diagnostics.AddError("Invalid Import ID", fmt.Sprintf(
+			"Expected import ID in the form 'domain/id' or 'domain/subdomain', got: '%s'.",
+			req.ID,
+		))
 		return
 	}
+
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil {
+		forwards, errs := r.client.URLForwards(ctx, domain)
+		if errs != nil && len(errs) != 0 {
+			for _, err := range errs {
+				addClientError(&resp.Diagnostics, err)
+			}
+			return
+		}
+
+		candidates := []*porkbun.URLForward{}
+		for _, forward := range forwards {
+			if porkbun.SameName(forward.Subdomain, key, domain) {
+				candidates = append(candidates, forward)
+			}
+		}
+
+		switch len(candidates) {
+		case 0:
+			resp.Diagnostics.AddError("No Matching URL Forward", fmt.Sprintf(
+				"Found no url forward for subdomain '%s' of '%s'.",
+				key,
+				domain,
+			))
+			return
+		case 1:
+			id = *candidates[0].ID
+		default:
+			var list strings.Builder
+			for _, forward := range candidates {
+				fmt.Fprintf(&list, "\n  - %s/%d (location: '%s')", domain, *forward.ID, forward.Location)
+			}
+			resp.Diagnostics.AddError("Ambiguous URL Forward", fmt.Sprintf(
+				"Found %d url forwards for subdomain '%s' of '%s'. "+
+					"Import by one of the following IDs instead:%s",
+				len(candidates),
+				key,
+				domain,
+				list.String(),
+			))
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domain)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 }
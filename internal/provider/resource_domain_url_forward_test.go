@@ -0,0 +1,99 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+func TestDomainURLForwardImportState(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forward := func(id string, subdomain string) map[string]string {
+			return map[string]string{
+				"id":          id,
+				"subdomain":   subdomain,
+				"location":    "https://example.net",
+				"type":        "temporary",
+				"includePath": "no",
+				"wildcard":    "no",
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"status": "SUCCESS",
+			"forwards": []map[string]string{
+				forward("1", "www"),
+				forward("2", ""),
+				forward("3", "blog"),
+				forward("4", "blog"),
+			},
+		})
+	}))
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &DomainURLForwardResource{
+		client: porkbun.NewClient(server.Client(), baseURL, "pk1_test", "sk1_test", nil),
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	tests := []struct {
+		id   string
+		want int64
+		err  string
+	}{
+		{id: "example.com/7", want: 7},
+		{id: "example.com/www", want: 1},
+		{id: "example.com/WWW", want: 1},
+		{id: "example.com/www.example.com", want: 1},
+		{id: "example.com/www.Example.com.", want: 1},
+		{id: "example.com/", want: 2},
+		{id: "example.com/@", want: 2},
+		{id: "example.com/example.com", want: 2},
+		{id: "example.com/mail", err: "No Matching URL Forward"},
+		{id: "example.com/www.example.net", err: "No Matching URL Forward"},
+		{id: "example.com/blog", err: "Ambiguous URL Forward"},
+		{id: "example.com", err: "Invalid Import ID"},
+	}
+
+	for _, test := range tests {
+		resp := &resource.ImportStateResponse{State: tfsdk.State{Schema: s, Raw: null}}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: test.id}, resp)
+		if test.err != "" {
+			if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != test.err {
+				t.Errorf("ImportState(%q) = %v, want error %q", test.id, resp.Diagnostics, test.err)
+			}
+			continue
+		}
+		if resp.Diagnostics.HasError() {
+			t.Errorf("ImportState(%q) = %v, want no error", test.id, resp.Diagnostics)
+			continue
+		}
+		var id types.Int64
+		resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+		if id.ValueInt64() != test.want {
+			t.Errorf("ImportState(%q) imported id %s, want %d", test.id, id, test.want)
+		}
+	}
+}
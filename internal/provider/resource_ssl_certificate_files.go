@@ -0,0 +1,526 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ resource.Resource = &SSLCertificateFilesResource{}
+var _ resource.ResourceWithConfigValidators = &SSLCertificateFilesResource{}
+var _ resource.ResourceWithModifyPlan = &SSLCertificateFilesResource{}
+
+var filePermissionRE = regexp.MustCompile(`^0?[0-7]{3}$`)
+
+type SSLCertificateFilesResource struct {
+	client *porkbun.Client
+}
+
+type SSLCertificateFilesResourceModel struct {
+	Domain                   types.String `tfsdk:"domain"`
+	CertificatePath          types.String `tfsdk:"certificate_path"`
+	ChainPath                types.String `tfsdk:"chain_path"`
+	FullChainPath            types.String `tfsdk:"full_chain_path"`
+	PrivateKeyPath           types.String `tfsdk:"private_key_path"`
+	FilePermission           types.String `tfsdk:"file_permission"`
+	PrivateKeyFilePermission types.String `tfsdk:"private_key_file_permission"`
+	CertificateFingerprint   types.String `tfsdk:"certificate_fingerprint"`
+	CertificateSHA256        types.String `tfsdk:"certificate_sha256"`
+	ChainSHA256              types.String `tfsdk:"chain_sha256"`
+	FullChainSHA256          types.String `tfsdk:"full_chain_sha256"`
+	PrivateKeySHA256         types.String `tfsdk:"private_key_sha256"`
+}
+
+// certificateFile is a file written by the resource, along with the state
+// attribute that holds the SHA-256 of its content.
+type certificateFile struct {
+	path       types.String
+	hash       *types.String
+	hashPath   path.Path
+	content    string
+	permission types.String
+}
+
+func (m *SSLCertificateFilesResourceModel) files() []certificateFile {
+	return []certificateFile{
+		{m.CertificatePath, &m.CertificateSHA256, path.Root("certificate_sha256"), "", m.FilePermission},
+		{m.ChainPath, &m.ChainSHA256, path.Root("chain_sha256"), "", m.FilePermission},
+		{m.FullChainPath, &m.FullChainSHA256, path.Root("full_chain_sha256"), "", m.FilePermission},
+		{m.PrivateKeyPath, &m.PrivateKeySHA256, path.Root("private_key_sha256"), "", m.PrivateKeyFilePermission},
+	}
+}
+
+func NewSSLCertificateFilesResource() resource.Resource {
+	return &SSLCertificateFilesResource{}
+}
+
+func (r *SSLCertificateFilesResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_certificate_files"
+}
+
+func (r *SSLCertificateFilesResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	pathAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: description,
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		}
+	}
+	permissionAttribute := func(description string, def string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: description,
+			Default:             stringdefault.StaticString(def),
+			Validators: []validator.String{
+				stringvalidator.RegexMatches(filePermissionRE, "must be an octal file mode, e.g. '0644'"),
+			},
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplace(),
+			},
+		}
+	}
+	hashAttribute := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: description,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Write the SSL certificate bundle for the domain to local files. " +
+			"Files are written atomically and only their SHA-256 is stored in the state. " +
+			"The files are replaced when Porkbun issues a new certificate or when they were modified on disk. " +
+			"Files that were rewritten by the time the old resource is destroyed are kept, " +
+			"so with `create_before_destroy` the files are never missing during a replacement.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"certificate_path":            pathAttribute("The path to write the certificate to."),
+			"chain_path":                  pathAttribute("The path to write the intermediate certificates to."),
+			"full_chain_path":             pathAttribute("The path to write the certificate followed by the intermediate certificates to."),
+			"private_key_path":            pathAttribute("The path to write the private key to."),
+			"file_permission":             permissionAttribute("The permission of the certificate files. Defaults to '0644'.", "0644"),
+			"private_key_file_permission": permissionAttribute("The permission of the private key file. Defaults to '0600'.", "0600"),
+			"certificate_fingerprint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hexadecimal SHA-256 fingerprint of the certificate written.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate_sha256": hashAttribute("The SHA-256 of the certificate file."),
+			"chain_sha256":       hashAttribute("The SHA-256 of the intermediate certificates file."),
+			"full_chain_sha256":  hashAttribute("The SHA-256 of the full chain file."),
+			"private_key_sha256": hashAttribute("The SHA-256 of the private key file."),
+		},
+	}
+}
+
+func (r *SSLCertificateFilesResource) ConfigValidators(
+	_ context.Context,
+) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("certificate_path"),
+			path.MatchRoot("chain_path"),
+			path.MatchRoot("full_chain_path"),
+			path.MatchRoot("private_key_path"),
+		),
+	}
+}
+
+func (r *SSLCertificateFilesResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+// ModifyPlan plans a replacement when a file was modified on disk or the
+// certificate at Porkbun changed since the files were written. The bundle is
+// only fetched when the resource already exists and isn't being replaced or
+// destroyed anyway.
+func (r *SSLCertificateFilesResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to compare on create and destroy, or before the provider is
+	// configured.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var state SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.configEqual(&state) {
+		// Replaced because of the configuration, the computed values are only
+		// known once written.
+		resp.Diagnostics.Append(plan.unknownComputed(ctx, &resp.Plan)...)
+		return
+	}
+
+	modified, err := state.modifiedFiles()
+	if err != nil {
+		resp.Diagnostics.AddError("File Not Read", err.Error())
+		return
+	}
+	resp.RequiresReplace.Append(modified...)
+
+	if len(modified) == 0 {
+		bundle, err := r.client.SSLBundle(ctx, plan.Domain.ValueString())
+		if err != nil {
+			addClientError(&resp.Diagnostics, err)
+			return
+		}
+		certs, err := parseCertificates(bundle.CertificateChain)
+		if err != nil {
+			resp.Diagnostics.AddError("Certificate Not Parsed", err.Error())
+			return
+		}
+		if certificateFingerprint(certs[0]) != state.CertificateFingerprint.ValueString() {
+			resp.RequiresReplace.Append(path.Root("certificate_fingerprint"))
+		}
+	}
+
+	// A newer certificate may be issued between plan and apply, so the
+	// values are only known once written.
+	if len(resp.RequiresReplace) != 0 {
+		resp.Diagnostics.Append(plan.unknownComputed(ctx, &resp.Plan)...)
+	}
+}
+
+// configEqual reports whether the configured attributes are the same, any
+// change to them requires replacement.
+func (m *SSLCertificateFilesResourceModel) configEqual(
+	other *SSLCertificateFilesResourceModel,
+) bool {
+	if !m.Domain.Equal(other.Domain) ||
+		!m.FilePermission.Equal(other.FilePermission) ||
+		!m.PrivateKeyFilePermission.Equal(other.PrivateKeyFilePermission) {
+		return false
+	}
+	files := m.files()
+	for i, file := range other.files() {
+		if !files[i].path.Equal(file.path) {
+			return false
+		}
+	}
+	return true
+}
+
+// unknownComputed sets the fingerprint and the SHA-256 of every file to
+// unknown in the plan.
+func (m *SSLCertificateFilesResourceModel) unknownComputed(
+	ctx context.Context,
+	plan *tfsdk.Plan,
+) diag.Diagnostics {
+	diags := plan.SetAttribute(ctx, path.Root("certificate_fingerprint"), types.StringUnknown())
+	for _, file := range m.files() {
+		diags.Append(plan.SetAttribute(ctx, file.hashPath, types.StringUnknown())...)
+	}
+	return diags
+}
+
+// modifiedFiles returns the SHA-256 attributes of the files whose content on
+// disk no longer matches what was written, including files that are missing.
+func (m *SSLCertificateFilesResourceModel) modifiedFiles() ([]path.Path, error) {
+	modified := []path.Path{}
+	for _, file := range m.files() {
+		if file.path.IsNull() {
+			continue
+		}
+		hash, err := fileSHA256(file.path.ValueString())
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf(
+				"Failed to read '%s' with the following error: '%s'.",
+				file.path.ValueString(),
+				err.Error(),
+			)
+		}
+		if hash != file.hash.ValueString() {
+			modified = append(modified, file.hashPath)
+		}
+	}
+	return modified, nil
+}
+
+func (r *SSLCertificateFilesResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateFilesResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	// The state holds what was written, it's compared with the files on disk
+	// and the certificate at Porkbun when planning.
+	var model SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateFilesResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	// Every attribute requires replacement, so there's nothing to update.
+	var model SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateFilesResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var model SSLCertificateFilesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for _, file := range model.files() {
+		if file.path.IsNull() {
+			continue
+		}
+		// A file rewritten since, e.g. by the replacement of this resource
+		// when it's created before this one is destroyed, isn't ours anymore.
+		hash, err := fileSHA256(file.path.ValueString())
+		if err == nil && hash != file.hash.ValueString() {
+			resp.Diagnostics.AddWarning("File Not Deleted", fmt.Sprintf(
+				"The file '%s' was rewritten since it was written by this resource, so it has been kept.",
+				file.path.ValueString(),
+			))
+			continue
+		}
+		err = os.Remove(file.path.ValueString())
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			resp.Diagnostics.AddError("File Not Deleted", fmt.Sprintf(
+				"Failed to delete '%s' with the following error: '%s'.",
+				file.path.ValueString(),
+				err.Error(),
+			))
+		}
+	}
+}
+
+// write fetches the bundle and writes the files configured in the model,
+// filling in the fingerprint and the SHA-256 of every file.
+func (r *SSLCertificateFilesResource) write(
+	ctx context.Context,
+	model *SSLCertificateFilesResourceModel,
+) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	domain := model.Domain.ValueString()
+
+	bundle, err := r.client.SSLBundle(ctx, domain)
+	if err != nil {
+		addClientError(&diags, err)
+		return diags
+	}
+
+	certs, err := parseCertificates(bundle.CertificateChain)
+	if err != nil {
+		diags.AddError("Certificate Not Parsed", err.Error())
+		return diags
+	}
+	intermediates, err := parseCertificates(bundle.IntermediateCertificate)
+	if err != nil {
+		intermediates = nil
+	}
+	chain, _ := orderCertificateChain(append(certs, intermediates...))
+
+	files := model.files()
+	files[0].content = encodeCertificates(chain[:1])
+	files[1].content = encodeCertificates(chain[1:])
+	files[2].content = encodeCertificates(chain)
+	files[3].content = strings.TrimRight(bundle.PrivateKey, "\n") + "\n"
+
+	for _, file := range files {
+		if file.path.IsNull() {
+			*file.hash = types.StringNull()
+			continue
+		}
+		mode, err := strconv.ParseUint(file.permission.ValueString(), 8, 32)
+		if err != nil {
+			diags.AddError("Invalid File Permission", fmt.Sprintf(
+				"Failed to parse file permission as an octal number with the following error: '%s'.",
+				err.Error(),
+			))
+			return diags
+		}
+		err = writeFileAtomic(file.path.ValueString(), []byte(file.content), os.FileMode(mode))
+		if err != nil {
+			diags.AddError("File Not Written", err.Error())
+			return diags
+		}
+		sum := sha256.Sum256([]byte(file.content))
+		*file.hash = types.StringValue(hex.EncodeToString(sum[:]))
+	}
+
+	model.CertificateFingerprint = types.StringValue(certificateFingerprint(chain[0]))
+	return diags
+}
+
+// writeFileAtomic writes the content to a temporary file next to the path and
+// renames it over the path, so that readers never see a partial file. Missing
+// directories are created searchable by whoever can read the file, i.e. 0700
+// for a private key written with 0600.
+func writeFileAtomic(name string, content []byte, mode os.FileMode) error {
+	dir := filepath.Dir(name)
+	err := os.MkdirAll(dir, 0o700|(mode&0o044)|(mode&0o044)>>2)
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to create the directory '%s' with the following error: '%s'.",
+			dir,
+			err.Error(),
+		)
+	}
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(name)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to create a temporary file for '%s' with the following error: '%s'.",
+			name,
+			err.Error(),
+		)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = tmp.Chmod(mode)
+	if err == nil {
+		_, err = tmp.Write(content)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), name)
+	}
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to write '%s' with the following error: '%s'.",
+			name,
+			err.Error(),
+		)
+	}
+	return nil
+}
+
+// fileSHA256 returns the hexadecimal SHA-256 of the file content.
+func fileSHA256(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,364 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+func TestWriteFileAtomicMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    os.FileMode
+		dirMode os.FileMode
+	}{
+		{"cert.pem", 0o644, 0o755},
+		{"key.pem", 0o600, 0o700},
+		{"group.pem", 0o640, 0o750},
+		{"readonly.pem", 0o400, 0o700},
+	}
+
+	for _, test := range tests {
+		dir := filepath.Join(t.TempDir(), "missing", "dir")
+		name := filepath.Join(dir, test.name)
+		err := writeFileAtomic(name, []byte("content\n"), test.mode)
+		if err != nil {
+			t.Fatalf("writeFileAtomic(%q) = %v", test.name, err)
+		}
+
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != test.mode {
+			t.Errorf("%s: file mode = %v, want %v", test.name, info.Mode().Perm(), test.mode)
+		}
+		// The umask may only take permissions away from the directory.
+		info, err = os.Stat(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm()&^test.dirMode != 0 || info.Mode().Perm()&0o700 != 0o700 {
+			t.Errorf("%s: directory mode = %v, want at most %v", test.name, info.Mode().Perm(), test.dirMode)
+		}
+	}
+}
+
+func TestWriteFileAtomicReplace(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "cert.pem")
+	if err := writeFileAtomic(name, []byte("old\n"), 0o444); err != nil {
+		t.Fatal(err)
+	}
+
+	// A reader holding the old file keeps reading the old content, rather
+	// than a truncated or partly written one.
+	old, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer old.Close()
+
+	// Replacing works even though the old file is read-only.
+	if err := writeFileAtomic(name, []byte("new\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := io.ReadAll(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "old\n" {
+		t.Errorf("old file content = %q, want %q", content, "old\n")
+	}
+	content, err = os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "new\n" {
+		t.Errorf("file content = %q, want %q", content, "new\n")
+	}
+	info, err := os.Stat(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o644 {
+		t.Errorf("file mode = %v, want %v", info.Mode().Perm(), os.FileMode(0o644))
+	}
+
+	// No temporary files are left behind.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		names := []string{}
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		t.Errorf("directory entries = %q, want only %q", names, "cert.pem")
+	}
+}
+
+func TestSSLCertificateFilesModifiedFiles(t *testing.T) {
+	sha256Hex := func(content string) string {
+		sum := sha256.Sum256([]byte(content))
+		return hex.EncodeToString(sum[:])
+	}
+
+	tests := []struct {
+		name     string
+		write    func(t *testing.T, dir string)
+		modified []path.Path
+	}{
+		{
+			name:     "unchanged",
+			write:    func(t *testing.T, dir string) {},
+			modified: []path.Path{},
+		},
+		{
+			name: "modified",
+			write: func(t *testing.T, dir string) {
+				writeTestFile(t, filepath.Join(dir, "cert.pem"), "tampered\n")
+			},
+			modified: []path.Path{path.Root("certificate_sha256")},
+		},
+		{
+			name: "missing",
+			write: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "key.pem")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			modified: []path.Path{path.Root("private_key_sha256")},
+		},
+		{
+			name: "mode only",
+			write: func(t *testing.T, dir string) {
+				if err := os.Chmod(filepath.Join(dir, "key.pem"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			modified: []path.Path{},
+		},
+		{
+			name: "several",
+			write: func(t *testing.T, dir string) {
+				writeTestFile(t, filepath.Join(dir, "cert.pem"), "")
+				writeTestFile(t, filepath.Join(dir, "key.pem"), "key\n\n")
+			},
+			modified: []path.Path{path.Root("certificate_sha256"), path.Root("private_key_sha256")},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeTestFile(t, filepath.Join(dir, "cert.pem"), "cert\n")
+			writeTestFile(t, filepath.Join(dir, "key.pem"), "key\n")
+			model := &SSLCertificateFilesResourceModel{
+				CertificatePath:   types.StringValue(filepath.Join(dir, "cert.pem")),
+				CertificateSHA256: types.StringValue(sha256Hex("cert\n")),
+				ChainPath:         types.StringNull(),
+				ChainSHA256:       types.StringNull(),
+				FullChainPath:     types.StringNull(),
+				FullChainSHA256:   types.StringNull(),
+				PrivateKeyPath:    types.StringValue(filepath.Join(dir, "key.pem")),
+				PrivateKeySHA256:  types.StringValue(sha256Hex("key\n")),
+			}
+			test.write(t, dir)
+
+			modified, err := model.modifiedFiles()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(modified, test.modified) {
+				t.Errorf("modifiedFiles() = %v, want %v", modified, test.modified)
+			}
+		})
+	}
+}
+
+func TestSSLCertificateFilesModifiedFilesUnreadable(t *testing.T) {
+	dir := t.TempDir()
+	model := &SSLCertificateFilesResourceModel{
+		// A directory can't be read as a file.
+		CertificatePath:   types.StringValue(dir),
+		CertificateSHA256: types.StringValue(""),
+	}
+	if _, err := model.modifiedFiles(); err == nil {
+		t.Error("modifiedFiles() = nil, want an error for an unreadable file")
+	}
+}
+
+func TestSSLCertificateFilesModifyPlan(t *testing.T) {
+	ctx := context.Background()
+
+	root := newTestCertificate(t, "Root", nil)
+	leaf := newTestCertificate(t, "example.com", root, "example.com")
+	fingerprint := certificateFingerprint(leaf.cert)
+
+	var calls atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"status":           "SUCCESS",
+			"certificatechain": encodeCertificates([]*x509.Certificate{leaf.cert, root.cert}),
+		})
+	}))
+	defer server.Close()
+	baseURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &SSLCertificateFilesResource{
+		client: porkbun.NewClient(server.Client(), baseURL, "pk1_test", "sk1_test", nil),
+	}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	s := schemaResp.Schema
+	null := tftypes.NewValue(s.Type().TerraformType(ctx), nil)
+
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "cert.pem"), "cert\n")
+	sum := sha256.Sum256([]byte("cert\n"))
+	written := SSLCertificateFilesResourceModel{
+		Domain:                   types.StringValue("example.com"),
+		CertificatePath:          types.StringValue(filepath.Join(dir, "cert.pem")),
+		ChainPath:                types.StringNull(),
+		FullChainPath:            types.StringNull(),
+		PrivateKeyPath:           types.StringNull(),
+		FilePermission:           types.StringValue("0644"),
+		PrivateKeyFilePermission: types.StringValue("0600"),
+		CertificateFingerprint:   types.StringValue(fingerprint),
+		CertificateSHA256:        types.StringValue(hex.EncodeToString(sum[:])),
+		ChainSHA256:              types.StringNull(),
+		FullChainSHA256:          types.StringNull(),
+		PrivateKeySHA256:         types.StringNull(),
+	}
+
+	tests := []struct {
+		name    string
+		state   func(m *SSLCertificateFilesResourceModel) bool
+		plan    func(m *SSLCertificateFilesResourceModel) bool
+		calls   int64
+		replace []path.Path
+		unknown bool
+	}{
+		{
+			name:  "unchanged",
+			calls: 1,
+		},
+		{
+			name: "create",
+			state: func(m *SSLCertificateFilesResourceModel) bool {
+				return false
+			},
+		},
+		{
+			name: "destroy",
+			plan: func(m *SSLCertificateFilesResourceModel) bool {
+				return false
+			},
+		},
+		{
+			name: "configuration changed",
+			plan: func(m *SSLCertificateFilesResourceModel) bool {
+				m.FilePermission = types.StringValue("0640")
+				return true
+			},
+			unknown: true,
+		},
+		{
+			name: "file modified",
+			state: func(m *SSLCertificateFilesResourceModel) bool {
+				m.CertificateSHA256 = types.StringValue("0")
+				return true
+			},
+			replace: []path.Path{path.Root("certificate_sha256")},
+			unknown: true,
+		},
+		{
+			name: "certificate rotated",
+			state: func(m *SSLCertificateFilesResourceModel) bool {
+				m.CertificateFingerprint = types.StringValue("0")
+				return true
+			},
+			calls:   1,
+			replace: []path.Path{path.Root("certificate_fingerprint")},
+			unknown: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: s, Raw: null}
+			model := written
+			if test.state == nil || test.state(&model) {
+				if diags := state.Set(ctx, &model); diags.HasError() {
+					t.Fatal(diags)
+				}
+			}
+			plan := tfsdk.Plan{Schema: s, Raw: null}
+			model = written
+			if test.plan == nil || test.plan(&model) {
+				if diags := plan.Set(ctx, &model); diags.HasError() {
+					t.Fatal(diags)
+				}
+			}
+
+			calls.Store(0)
+			resp := &resource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, resource.ModifyPlanRequest{State: state, Plan: plan}, resp)
+			if resp.Diagnostics.HasError() {
+				t.Fatal(resp.Diagnostics)
+			}
+
+			if calls.Load() != test.calls {
+				t.Errorf("fetched the bundle %d times, want %d", calls.Load(), test.calls)
+			}
+			if len(resp.RequiresReplace) != len(test.replace) ||
+				len(test.replace) != 0 && !reflect.DeepEqual([]path.Path(resp.RequiresReplace), test.replace) {
+				t.Errorf("RequiresReplace = %v, want %v", resp.RequiresReplace, test.replace)
+			}
+			if plan.Raw.IsNull() {
+				return
+			}
+			var planned types.String
+			resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("certificate_fingerprint"), &planned)...)
+			if planned.IsUnknown() != test.unknown {
+				t.Errorf("planned fingerprint = %v, want unknown: %t", planned, test.unknown)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, name string, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
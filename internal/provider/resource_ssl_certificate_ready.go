@@ -0,0 +1,413 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	"github.com/synthmoe/terraform-provider-porkbun/internal/porkbun"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SSLCertificateReadyResource{}
+
+// errCertificateNotReady is wrapped by errors that are expected while Porkbun
+// is still issuing the certificate, so they are waited out.
+var errCertificateNotReady = errors.New("certificate not ready")
+
+type SSLCertificateReadyResource struct {
+	client *porkbun.Client
+}
+
+type SSLCertificateReadyResourceModel struct {
+	Domain                 types.String `tfsdk:"domain"`
+	Names                  types.Set    `tfsdk:"names"`
+	Timeout                types.Int64  `tfsdk:"timeout"`
+	PollInterval           types.Int64  `tfsdk:"poll_interval"`
+	MaxPollInterval        types.Int64  `tfsdk:"max_poll_interval"`
+	CertificateFingerprint types.String `tfsdk:"certificate_fingerprint"`
+	SANs                   types.List   `tfsdk:"sans"`
+	NotAfter               types.String `tfsdk:"not_after"`
+}
+
+func NewSSLCertificateReadyResource() resource.Resource {
+	return &SSLCertificateReadyResource{}
+}
+
+func (r *SSLCertificateReadyResource) Metadata(
+	_ context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_ssl_certificate_ready"
+}
+
+func (r *SSLCertificateReadyResource) Schema(
+	_ context.Context,
+	_ resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Wait until the SSL certificate Porkbun issued for the domain covers the names, " +
+			"e.g. after adding a subdomain, including while Porkbun has no certificate for it yet. " +
+			"Reference `certificate_fingerprint` to make other resources wait as well. " +
+			"The wait starts over if a later certificate no longer covers the names.",
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Your domain.",
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 253),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"names": schema.SetAttribute{
+				Required: true,
+				MarkdownDescription: "A set of names the certificate must cover, e.g. 'www.example.com'. " +
+					"Names are compared ignoring case and wildcard names of the certificate cover a single label.",
+				ElementType: types.StringType,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(
+						stringvalidator.LengthBetween(1, 253),
+					),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How long to wait in seconds before failing. Defaults to 1800.",
+				Default:             int64default.StaticInt64(1800),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"poll_interval": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "How long to wait in seconds before checking again at first. Defaults to 15.",
+				Default:             int64default.StaticInt64(15),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_poll_interval": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "The maximum time to wait in seconds between checks, " +
+					"the wait doubles after every check up to it. Defaults to 120.",
+				Default: int64default.StaticInt64(120),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"certificate_fingerprint": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The hexadecimal SHA-256 fingerprint of the certificate covering the names.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sans": schema.ListAttribute{
+				Computed:            true,
+				MarkdownDescription: "The subject alternative names of the certificate.",
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"not_after": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The time the certificate expires, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SSLCertificateReadyResource) Configure(
+	_ context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	data, ok := req.ProviderData.(*PorkbunProviderData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *PorkbunProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = data.Client
+}
+
+func (r *SSLCertificateReadyResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var model SSLCertificateReadyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	var names []string
+	resp.Diagnostics.Append(model.Names.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	timeout := time.Duration(model.Timeout.ValueInt64()) * time.Second
+	interval := time.Duration(model.PollInterval.ValueInt64()) * time.Second
+	maxInterval := time.Duration(model.MaxPollInterval.ValueInt64()) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	for {
+		cert, missing, err := r.check(ctx, domain, names)
+		if err != nil && !errors.Is(err, errCertificateNotReady) {
+			addClientError(&resp.Diagnostics, err)
+			return
+		}
+		if err == nil && len(missing) == 0 {
+			resp.Diagnostics.Append(certificateReadyToModel(ctx, cert, &model)...)
+			break
+		}
+
+		wait := min(interval, time.Until(deadline))
+		if wait <= 0 {
+			if err != nil {
+				resp.Diagnostics.AddError("Certificate Not Ready", fmt.Sprintf(
+					"The certificate of '%s' is still not available after %s, the last error was: '%s'.",
+					domain,
+					timeout,
+					err.Error(),
+				))
+				return
+			}
+			resp.Diagnostics.AddError("Certificate Not Ready", fmt.Sprintf(
+				"The certificate of '%s' still doesn't cover the following names after %s: '%s'.",
+				domain,
+				timeout,
+				strings.Join(missing, "', '"),
+			))
+			return
+		}
+		select {
+		case <-ctx.Done():
+			resp.Diagnostics.AddError("Certificate Not Ready", fmt.Sprintf(
+				"Waiting for the certificate of '%s' was cancelled.",
+				domain,
+			))
+			return
+		case <-time.After(wait):
+		}
+		interval = min(interval*2, maxInterval)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateReadyResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var model SSLCertificateReadyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain := model.Domain.ValueString()
+
+	var names []string
+	resp.Diagnostics.Append(model.Names.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, missing, err := r.check(ctx, domain, names)
+	if errors.Is(err, errCertificateNotReady) {
+		resp.Diagnostics.AddWarning("Certificate Not Available", fmt.Sprintf(
+			"The certificate of '%s' is not available, most likely Porkbun is issuing a new one: '%s'. "+
+				"It has been removed from the state and will be planned for creation to wait again.",
+			domain,
+			err.Error(),
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		addClientError(&resp.Diagnostics, err)
+		return
+	}
+	if len(missing) != 0 {
+		resp.Diagnostics.AddWarning("Certificate No Longer Covers Names", fmt.Sprintf(
+			"The current certificate of '%s' doesn't cover the following names: '%s'. "+
+				"It has been removed from the state and will be planned for creation to wait again.",
+			domain,
+			strings.Join(missing, "', '"),
+		))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(certificateReadyToModel(ctx, cert, &model)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateReadyResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	// Only the wait settings can change in place, they don't affect a
+	// certificate that is ready already.
+	var model SSLCertificateReadyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var state SSLCertificateReadyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	model.CertificateFingerprint = state.CertificateFingerprint
+	model.SANs = state.SANs
+	model.NotAfter = state.NotAfter
+	resp.Diagnostics.Append(resp.State.Set(ctx, &model)...)
+}
+
+func (r *SSLCertificateReadyResource) Delete(
+	_ context.Context,
+	_ resource.DeleteRequest,
+	_ *resource.DeleteResponse,
+) {
+	// There's nothing to delete, the certificate is managed by Porkbun.
+}
+
+// check returns the current certificate of the domain and the names it
+// doesn't cover yet, sorted. The error wraps errCertificateNotReady if there
+// is no certificate to check yet.
+func (r *SSLCertificateReadyResource) check(
+	ctx context.Context,
+	domain string,
+	names []string,
+) (
+	*x509.Certificate,
+	[]string,
+	error,
+) {
+	bundle, err := r.client.SSLBundle(ctx, domain)
+	if err != nil {
+		if isCertificateUnavailable(err) {
+			return nil, nil, fmt.Errorf("%w: %w", errCertificateNotReady, err)
+		}
+		return nil, nil, err
+	}
+	certs, err := parseCertificates(bundle.CertificateChain)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", errCertificateNotReady, err)
+	}
+
+	missing := []string{}
+	for _, name := range names {
+		if !certificateCovers(certs[0], name) {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return certs[0], missing, nil
+}
+
+// isCertificateUnavailable reports whether Porkbun refused to return the
+// bundle because there is no certificate for the domain yet, e.g. while it's
+// being issued or renewed.
+func isCertificateUnavailable(err error) bool {
+	var apiErr *porkbun.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != "ERROR" {
+		return false
+	}
+	if errors.Is(apiErr, porkbun.ErrNotFound) {
+		return true
+	}
+	message := strings.ToLower(apiErr.Message)
+	return strings.Contains(message, "not available") ||
+		strings.Contains(message, "not ready") ||
+		strings.Contains(message, "not yet") ||
+		strings.Contains(message, "no ssl") ||
+		strings.Contains(message, "no certificate") ||
+		strings.Contains(message, "being generated") ||
+		strings.Contains(message, "being issued")
+}
+
+// certificateCovers reports whether a DNS name of the certificate matches the
+// name, where a wildcard covers a single label.
+func certificateCovers(cert *x509.Certificate, name string) bool {
+	name = canonicalHostname(name)
+	for _, san := range cert.DNSNames {
+		san = canonicalHostname(san)
+		if san == name {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(san, "*."); ok {
+			label, rest, found := strings.Cut(name, ".")
+			if found && label != "" && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func certificateReadyToModel(
+	ctx context.Context,
+	cert *x509.Certificate,
+	model *SSLCertificateReadyResourceModel,
+) diag.Diagnostics {
+	sans, diags := types.ListValueFrom(ctx, types.StringType, certificateSANs(cert))
+	model.CertificateFingerprint = types.StringValue(certificateFingerprint(cert))
+	model.SANs = sans
+	model.NotAfter = types.StringValue(cert.NotAfter.UTC().Format(time.RFC3339))
+	return diags
+}
@@ -0,0 +1,42 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import "testing"
+
+func TestCertificateCovers(t *testing.T) {
+	root := newTestCertificate(t, "Root", nil)
+	cert := newTestCertificate(t, "example.com", root, "example.com", "*.example.com", "Mail.Example.NET").cert
+
+	tests := []struct {
+		name    string
+		covered bool
+	}{
+		{"example.com", true},
+		{"EXAMPLE.com", true},
+		{"example.com.", true},
+		{"mail.example.net", true},
+		{"www.example.com", true},
+		{"WWW.Example.Com.", true},
+		{"a.b.example.com", false},
+		{".example.com", false},
+		{"*.example.com", true},
+		{"example.net", false},
+		{"www.example.net", false},
+		{"notexample.com", false},
+		{"", false},
+	}
+
+	for _, test := range tests {
+		got := certificateCovers(cert, test.name)
+		if got != test.covered {
+			t.Errorf("certificateCovers(%q) = %t, want %t", test.name, got, test.covered)
+		}
+	}
+
+	apex := newTestCertificate(t, "example.org", root, "*.example.org").cert
+	if certificateCovers(apex, "example.org") {
+		t.Errorf("certificateCovers(%q) = true for only a wildcard, want false", "example.org")
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright (c) Saba Gogichaishvili
+// SPDX-License-Identifier: ISC
+
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// newHTTPClient builds a dedicated HTTP client for the Porkbun API so that
+// the provider settings never leak into http.DefaultClient. A nil proxy falls
+// back to the standard HTTP_PROXY, HTTPS_PROXY and NO_PROXY variables.
+func newHTTPClient(
+	timeout time.Duration,
+	proxy *url.URL,
+	caBundle string,
+	insecureSkipVerify bool,
+) (
+	*http.Client,
+	error,
+) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxy != nil {
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecureSkipVerify,
+	}
+	if caBundle != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(caBundle)) {
+			return nil, fmt.Errorf(
+				"Failed to parse any PEM encoded certificates from the CA bundle.",
+			)
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}